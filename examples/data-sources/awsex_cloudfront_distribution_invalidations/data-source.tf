@@ -0,0 +1,8 @@
+data "awsex_cloudfront_distribution_invalidations" "in_progress" {
+  distribution_id = "E1234567890ABC"
+  status          = "InProgress"
+}
+
+output "invalidation_in_progress" {
+  value = data.awsex_cloudfront_distribution_invalidations.in_progress.in_progress
+}
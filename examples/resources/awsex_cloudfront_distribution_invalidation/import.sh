@@ -0,0 +1,4 @@
+terraform import awsex_cloudfront_distribution_invalidation.example E2QWRUHAPOMQZL/I2J0I21PCUYOIK
+
+# The invalidation's ARN may also be used
+terraform import awsex_cloudfront_distribution_invalidation.example arn:aws:cloudfront::123456789012:distribution/E2QWRUHAPOMQZL/invalidation/I2J0I21PCUYOIK
@@ -0,0 +1,10 @@
+locals {
+  site_dir = "${path.module}/public"
+}
+
+resource "awsex_cloudfront_distribution_manifest_invalidation" "site" {
+  distribution_id = "E1234567890ABC"
+  manifest = {
+    for file in fileset(local.site_dir, "**") : "/${file}" => filemd5("${local.site_dir}/${file}")
+  }
+}
@@ -0,0 +1,65 @@
+package conns
+
+import (
+	"context"
+	"fmt"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// assumeRoleResult is the result of assuming a role that is shared by concurrent callers
+type assumeRoleResult struct {
+	client *Client
+	diags  diag.Diagnostics
+}
+
+// AssumeRole returns a client that assumes role after resolving the provider's credentials
+// This uses the same awsbase configuration as the provider, so any role configured on the provider is assumed first
+// Clients are cached by role so that each role is only assumed once
+func (c *Client) AssumeRole(ctx context.Context, role awsbase.AssumeRole) (*Client, diag.Diagnostics) {
+	key := fmt.Sprintf("%s|%s|%s", role.RoleARN, role.ExternalID, role.SessionName)
+	if assumed, ok := c.cachedAssumedClient(key); ok {
+		return assumed, nil
+	}
+
+	// Concurrent calls for the same role share one STS request, which does not block the other roles
+	result, _, _ := c.assumeGroup.Do(key, func() (interface{}, error) {
+		if assumed, ok := c.cachedAssumedClient(key); ok {
+			return assumeRoleResult{client: assumed}, nil
+		}
+
+		baseConfig := c.BaseConfig
+		baseConfig.AssumeRole = append(append([]awsbase.AssumeRole{}, c.BaseConfig.AssumeRole...), role)
+		_, cfg, basediags := awsbase.GetAwsConfig(ctx, &baseConfig)
+		diags := FromAwsbaseDiags(basediags)
+		if diags.HasError() {
+			return assumeRoleResult{diags: diags}, nil
+		}
+
+		assumed := &Client{
+			Config:            cfg,
+			BaseConfig:        baseConfig,
+			Endpoints:         c.Endpoints,
+			MaxConcurrency:    c.MaxConcurrency,
+			RateLimiter:       c.RateLimiter,
+			DefaultTimeouts:   c.DefaultTimeouts,
+			SkipInvalidations: c.SkipInvalidations,
+		}
+		c.assumedMu.Lock()
+		defer c.assumedMu.Unlock()
+		if c.assumed == nil {
+			c.assumed = map[string]*Client{}
+		}
+		c.assumed[key] = assumed
+		return assumeRoleResult{client: assumed, diags: diags}, nil
+	})
+	assumed := result.(assumeRoleResult)
+	return assumed.client, assumed.diags
+}
+
+func (c *Client) cachedAssumedClient(key string) (*Client, bool) {
+	c.assumedMu.Lock()
+	defer c.assumedMu.Unlock()
+	assumed, ok := c.assumed[key]
+	return assumed, ok
+}
@@ -0,0 +1,79 @@
+package conns
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAssumeRoleConcurrent(t *testing.T) {
+	const blockedRoleArn = "arn:aws:iam::111111111111:role/blocked"
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		requests.Add(1)
+		if r.PostForm.Get("RoleArn") == blockedRoleArn {
+			started <- struct{}{}
+			<-release
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(servicemocks.MockStsAssumeRoleValidResponseBody))
+	}))
+	defer server.Close()
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	defer unblock()
+	client := &Client{BaseConfig: awsbase.Config{
+		AccessKey:                     servicemocks.MockStaticAccessKey,
+		SecretKey:                     servicemocks.MockStaticSecretKey,
+		Region:                        "us-east-1",
+		StsEndpoint:                   server.URL,
+		SkipCredsValidation:           true,
+		SkipRequestingAccountId:       true,
+		EC2MetadataServiceEnableState: imds.ClientDisabled,
+		MaxRetries:                    1,
+	}}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	blocked := make([]*Client, 3)
+	for i := range blocked {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			blocked[i], _ = client.AssumeRole(ctx, awsbase.AssumeRole{RoleARN: blockedRoleArn})
+		}()
+	}
+
+	// Another role is assumed while the blocked role waits for STS
+	<-started
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, diags := client.AssumeRole(ctx, awsbase.AssumeRole{RoleARN: "arn:aws:iam::222222222222:role/other"}); diags.HasError() {
+			t.Errorf("unexpected error: %v", diags)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected another role to be assumed while the blocked role waits for STS")
+	}
+
+	unblock()
+	wg.Wait()
+	if blocked[0] == nil || blocked[0] != blocked[1] || blocked[0] != blocked[2] {
+		t.Errorf("expected concurrent calls for the same role to share a client, got %v", blocked)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 1 STS request for each role, got %d", got)
+	}
+}
@@ -1,14 +1,64 @@
 package conns
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/sync/singleflight"
+	"sync"
 )
 
+// DefaultMaxConcurrency is the maximum number of concurrent API operations when one is not configured
+const DefaultMaxConcurrency = 10
+
 type Client struct {
-	Config aws.Config
+	Config     aws.Config
+	BaseConfig awsbase.Config
+	Endpoints  Endpoints
+	// MaxConcurrency is the default maximum number of concurrent API operations for a single resource
+	MaxConcurrency int
+	// RateLimiter limits the rate of API requests across every resource; nil disables rate limiting
+	RateLimiter *RateLimiter
+	// DefaultTimeouts are used by resources that do not configure `timeouts`
+	DefaultTimeouts Timeouts
+	// SkipInvalidations records invalidations as skipped instead of creating them (e.g. in preview environments)
+	SkipInvalidations bool
+
+	assumedMu   sync.Mutex
+	assumed     map[string]*Client
+	assumeGroup singleflight.Group
+
+	accountOnce  sync.Once
+	accountId    string
+	partition    string
+	accountDiags diag.Diagnostics
+}
+
+// Endpoints contains base endpoint overrides for each service client
+// An empty value will use the default endpoint resolution for the service
+type Endpoints struct {
+	Cloudfront string
 }
 
 func (c *Client) Cloudfront() *cloudfront.Client {
-	return cloudfront.NewFromConfig(c.Config)
+	return cloudfront.NewFromConfig(c.Config, func(o *cloudfront.Options) {
+		if c.Endpoints.Cloudfront != "" {
+			o.BaseEndpoint = aws.String(c.Endpoints.Cloudfront)
+		}
+		if c.RateLimiter != nil {
+			o.APIOptions = append(o.APIOptions, c.RateLimiter.addRateLimitMiddleware)
+		}
+	})
+}
+
+// AccountIdAndPartition returns the AWS account ID and partition of the configured credentials
+// The lookup is only performed once and only when a resource needs it
+func (c *Client) AccountIdAndPartition(ctx context.Context) (string, string, diag.Diagnostics) {
+	c.accountOnce.Do(func() {
+		accountId, partition, basediags := awsbase.GetAwsAccountIDAndPartition(ctx, c.Config, &c.BaseConfig)
+		c.accountId, c.partition, c.accountDiags = accountId, partition, FromAwsbaseDiags(basediags)
+	})
+	return c.accountId, c.partition, c.accountDiags
 }
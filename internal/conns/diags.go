@@ -0,0 +1,20 @@
+package conns
+
+import (
+	awsbasediag "github.com/hashicorp/aws-sdk-go-base/v2/diag"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// FromAwsbaseDiags converts diagnostics produced by awsbase into terraform framework diagnostics
+func FromAwsbaseDiags(basediags awsbasediag.Diagnostics) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, d := range basediags {
+		switch int(d.Severity()) {
+		case int(diag.SeverityError):
+			diags.AddError(d.Summary(), d.Detail())
+		case int(diag.SeverityWarning):
+			diags.AddWarning(d.Summary(), d.Detail())
+		}
+	}
+	return diags
+}
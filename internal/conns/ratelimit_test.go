@@ -0,0 +1,35 @@
+package conns
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewRateLimiter(10)
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first 10 requests use the burst, the remaining 5 are spaced 100ms apart
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	limiter := NewRateLimiter(0.1)
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Error("expected an error when the context is cancelled")
+	}
+}
@@ -0,0 +1,43 @@
+package conns
+
+import (
+	"time"
+)
+
+// DefaultTimeout is the timeout of an operation when neither the resource nor the provider configures one
+const DefaultTimeout = 30 * time.Minute
+
+// Timeouts contains the provider's default timeout for each resource operation; zero uses DefaultTimeout
+type Timeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// CreateTimeout returns the default timeout for creating a resource
+func (t Timeouts) CreateTimeout() time.Duration {
+	return orDefaultTimeout(t.Create)
+}
+
+// ReadTimeout returns the default timeout for reading a resource or data source
+func (t Timeouts) ReadTimeout() time.Duration {
+	return orDefaultTimeout(t.Read)
+}
+
+// UpdateTimeout returns the default timeout for updating a resource
+func (t Timeouts) UpdateTimeout() time.Duration {
+	return orDefaultTimeout(t.Update)
+}
+
+// DeleteTimeout returns the default timeout for deleting a resource
+func (t Timeouts) DeleteTimeout() time.Duration {
+	return orDefaultTimeout(t.Delete)
+}
+
+func orDefaultTimeout(timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return DefaultTimeout
+}
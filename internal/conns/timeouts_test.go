@@ -0,0 +1,22 @@
+package conns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeouts(t *testing.T) {
+	timeouts := Timeouts{Create: 5 * time.Minute, Delete: time.Hour}
+	if got := timeouts.CreateTimeout(); got != 5*time.Minute {
+		t.Errorf("expected configured create timeout, got %s", got)
+	}
+	if got := timeouts.ReadTimeout(); got != DefaultTimeout {
+		t.Errorf("expected default read timeout, got %s", got)
+	}
+	if got := timeouts.UpdateTimeout(); got != DefaultTimeout {
+		t.Errorf("expected default update timeout, got %s", got)
+	}
+	if got := timeouts.DeleteTimeout(); got != time.Hour {
+		t.Errorf("expected configured delete timeout, got %s", got)
+	}
+}
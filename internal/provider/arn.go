@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -28,6 +28,10 @@ var (
 // * Have a non-empty resource part
 // * Pass the supplied checks
 type ArnValidator struct {
+	// Service, if set, must match the service of the ARN (e.g. `cloudfront`)
+	Service string
+	// ResourcePrefix, if set, must prefix the resource part of the ARN (e.g. `distribution/`)
+	ResourcePrefix string
 }
 
 func (v ArnValidator) Description(ctx context.Context) string {
@@ -48,7 +52,7 @@ func (v ArnValidator) ValidateString(ctx context.Context, request validator.Stri
 	}
 	errs := v.validate(request.Path.String(), value)
 	for _, err := range errs {
-		response.Diagnostics.Append(diag.NewErrorDiagnostic(err.Error(), ""))
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid ARN", err.Error())
 	}
 }
 
@@ -77,5 +81,13 @@ func (v ArnValidator) validate(path, value string) []error {
 		errs = append(errs, fmt.Errorf("%q (%s) is an invalid ARN: missing resource value", path, value))
 	}
 
+	if v.Service != "" && parsedARN.Service != v.Service {
+		errs = append(errs, fmt.Errorf("%q (%s) is an invalid ARN: invalid service value (expecting %q)", path, value, v.Service))
+	}
+
+	if v.ResourcePrefix != "" && !strings.HasPrefix(parsedARN.Resource, v.ResourcePrefix) {
+		errs = append(errs, fmt.Errorf("%q (%s) is an invalid ARN: invalid resource value (expecting prefix %q)", path, value, v.ResourcePrefix))
+	}
+
 	return errs
 }
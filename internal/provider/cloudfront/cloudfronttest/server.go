@@ -0,0 +1,221 @@
+// Package cloudfronttest provides a fake CloudFront API for tests
+package cloudfronttest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ListPageSize is the number of invalidations in each page listed by the server
+const ListPageSize = 2
+
+// Server is a fake CloudFront API that creates, gets, and lists invalidations
+// CreateInvalidation fails for the distributions in errors with the configured error code
+// Like CloudFront, it returns the existing invalidation when a caller reference is reused
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	errors        map[string]string
+	requests      map[string]int
+	invalidations map[string]map[string]string
+	summaries     map[string][]cftypes.InvalidationSummary
+	listRequests  map[string]int
+}
+
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		errors:        map[string]string{},
+		requests:      map[string]int{},
+		invalidations: map[string]map[string]string{},
+		summaries:     map[string][]cftypes.InvalidationSummary{},
+		listRequests:  map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a client that sends CloudFront requests to the server
+func (s *Server) Client() *conns.Client {
+	return &conns.Client{
+		Config: aws.Config{
+			Region:           "us-east-1",
+			Credentials:      aws.AnonymousCredentials{},
+			RetryMaxAttempts: 1,
+		},
+		BaseConfig: awsbase.Config{
+			Region:                  "us-east-1",
+			SkipCredsValidation:     true,
+			SkipRequestingAccountId: true,
+		},
+		Endpoints: conns.Endpoints{Cloudfront: s.URL},
+	}
+}
+
+// SetError fails CreateInvalidation for a distribution with code, or succeeds if code is empty
+func (s *Server) SetError(distributionId string, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code == "" {
+		delete(s.errors, distributionId)
+	} else {
+		s.errors[distributionId] = code
+	}
+}
+
+// Requests returns the number of CreateInvalidation requests for a distribution
+func (s *Server) Requests(distributionId string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[distributionId]
+}
+
+// Invalidations returns the number of distinct invalidations created for a distribution
+func (s *Server) Invalidations(distributionId string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.invalidations[distributionId])
+}
+
+// SetSummaries sets the invalidations that are listed for a distribution, most recent first
+func (s *Server) SetSummaries(distributionId string, summaries []cftypes.InvalidationSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summaries[distributionId] = summaries
+}
+
+// ListRequests returns the number of ListInvalidations requests for a distribution
+func (s *Server) ListRequests(distributionId string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listRequests[distributionId]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// /2020-05-31/distribution/<distribution-id>/invalidation[/<id>]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[1] != "distribution" || parts[3] != "invalidation" {
+		http.NotFound(w, r)
+		return
+	}
+	distributionId := parts[2]
+	w.Header().Set("Content-Type", "text/xml")
+
+	if r.Method == http.MethodGet && len(parts) == 5 {
+		s.mu.Lock()
+		callerReference := "reference"
+		for reference, id := range s.invalidations[distributionId] {
+			if id == parts[4] {
+				callerReference = reference
+			}
+		}
+		s.mu.Unlock()
+		fmt.Fprint(w, invalidationXml(parts[4], "Completed", "/*", callerReference))
+		return
+	}
+	if r.Method == http.MethodGet {
+		s.list(w, r, distributionId)
+		return
+	}
+
+	var batch struct {
+		CallerReference string   `xml:"CallerReference"`
+		Paths           []string `xml:"Paths>Items>Path"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.requests[distributionId]++
+	code := s.errors[distributionId]
+	id := ""
+	if code == "" {
+		id = s.invalidationId(distributionId, batch.CallerReference)
+	}
+	s.mu.Unlock()
+	if code != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `<?xml version="1.0"?>
+<ErrorResponse xmlns="http://cloudfront.amazonaws.com/doc/2020-05-31/"><Error><Type>Sender</Type><Code>%s</Code><Message>%s</Message></Error><RequestId>1</RequestId></ErrorResponse>`, code, code)
+		return
+	}
+	path := ""
+	if len(batch.Paths) > 0 {
+		path = batch.Paths[0]
+	}
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, invalidationXml(id, "InProgress", path, batch.CallerReference))
+}
+
+// invalidationId returns the ID of the invalidation with callerReference, creating it if necessary
+// The first invalidation of a distribution is I<distribution-id>, and later ones append -<n>
+func (s *Server) invalidationId(distributionId string, callerReference string) string {
+	ids, ok := s.invalidations[distributionId]
+	if !ok {
+		ids = map[string]string{}
+		s.invalidations[distributionId] = ids
+	}
+	if id, ok := ids[callerReference]; ok {
+		return id
+	}
+	id := "I" + distributionId
+	if len(ids) > 0 {
+		id = fmt.Sprintf("%s-%d", id, len(ids)+1)
+	}
+	ids[callerReference] = id
+	return id
+}
+
+func invalidationXml(id string, status string, path string, callerReference string) string {
+	return fmt.Sprintf(`<?xml version="1.0"?>
+<Invalidation xmlns="http://cloudfront.amazonaws.com/doc/2020-05-31/">
+  <Id>%s</Id>
+  <Status>%s</Status>
+  <CreateTime>2024-01-01T00:00:00Z</CreateTime>
+  <InvalidationBatch>
+    <Paths><Quantity>1</Quantity><Items><Path>%s</Path></Items></Paths>
+    <CallerReference>%s</CallerReference>
+  </InvalidationBatch>
+</Invalidation>`, id, status, path, callerReference)
+}
+
+// list responds with pages of ListPageSize summaries, using the index of the next summary as the marker
+func (s *Server) list(w http.ResponseWriter, r *http.Request, distributionId string) {
+	s.mu.Lock()
+	s.listRequests[distributionId]++
+	summaries := s.summaries[distributionId]
+	s.mu.Unlock()
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("Marker"))
+	end := min(start+ListPageSize, len(summaries))
+	items := ""
+	for _, summary := range summaries[start:end] {
+		items += fmt.Sprintf("<InvalidationSummary><Id>%s</Id><CreateTime>%s</CreateTime><Status>%s</Status></InvalidationSummary>",
+			aws.ToString(summary.Id), aws.ToTime(summary.CreateTime).Format(time.RFC3339), aws.ToString(summary.Status))
+	}
+	nextMarker := ""
+	if end < len(summaries) {
+		nextMarker = fmt.Sprintf("<NextMarker>%d</NextMarker>", end)
+	}
+	fmt.Fprintf(w, `<?xml version="1.0"?>
+<InvalidationList xmlns="http://cloudfront.amazonaws.com/doc/2020-05-31/">
+  <Marker>%d</Marker>%s
+  <MaxItems>%d</MaxItems>
+  <IsTruncated>%t</IsTruncated>
+  <Quantity>%d</Quantity>
+  <Items>%s</Items>
+</InvalidationList>`, start, nextMarker, ListPageSize, end < len(summaries), end-start, items)
+}
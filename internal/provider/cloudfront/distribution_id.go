@@ -0,0 +1,42 @@
+package cloudfront
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"regexp"
+	"strings"
+)
+
+// DistributionResourcePrefix is the prefix of the resource part of a distribution ARN
+// e.g. arn:aws:cloudfront::123456789012:distribution/E1234567890ABC
+const DistributionResourcePrefix = "distribution/"
+
+// distributionIdRegexp matches the form of the IDs that CloudFront assigns to distributions
+var distributionIdRegexp = regexp.MustCompile(`^[A-Z0-9]{13,14}$`)
+
+// IsDistributionId returns true if value has the form of a distribution ID
+func IsDistributionId(value string) bool {
+	return distributionIdRegexp.MatchString(value)
+}
+
+// DistributionId returns the distribution ID from a distribution ARN
+// Any other value (including a distribution ID) is returned as-is
+func DistributionId(value string) string {
+	if !arn.IsARN(value) {
+		return value
+	}
+	parsed, err := arn.Parse(value)
+	if err != nil || parsed.Service != "cloudfront" || !strings.HasPrefix(parsed.Resource, DistributionResourcePrefix) {
+		return value
+	}
+	return strings.TrimPrefix(parsed.Resource, DistributionResourcePrefix)
+}
+
+// DistributionAccountId returns the AWS account ID from a distribution ARN
+// An empty string is returned for any other value (including a distribution ID)
+func DistributionAccountId(value string) string {
+	if DistributionId(value) == value {
+		return ""
+	}
+	parsed, _ := arn.Parse(value)
+	return parsed.AccountID
+}
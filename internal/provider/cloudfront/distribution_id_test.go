@@ -0,0 +1,64 @@
+package cloudfront
+
+import (
+	"testing"
+)
+
+func TestDistributionId(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "id", value: "E1234567890ABC", want: "E1234567890ABC"},
+		{name: "arn", value: "arn:aws:cloudfront::123456789012:distribution/E1234567890ABC", want: "E1234567890ABC"},
+		{name: "arn in other partition", value: "arn:aws-cn:cloudfront::123456789012:distribution/E1234567890ABC", want: "E1234567890ABC"},
+		{name: "other service", value: "arn:aws:s3:::my-bucket/distribution/E1234567890ABC", want: "arn:aws:s3:::my-bucket/distribution/E1234567890ABC"},
+		{name: "other resource type", value: "arn:aws:cloudfront::123456789012:function/my-function", want: "arn:aws:cloudfront::123456789012:function/my-function"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DistributionId(test.value); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIsDistributionId(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "E1234567890ABC", want: true},
+		{value: "E2QWRUHAPOMQZL", want: true},
+		{value: "E123456789ABC", want: true},
+		{value: "e1234567890abc", want: false},
+		{value: "E1234567890AB", want: true},
+		{value: "E123456789012", want: true},
+		{value: "E12345678901", want: false},
+		{value: "E-234567890ABC", want: false},
+		{value: "", want: false},
+	}
+	for _, test := range tests {
+		if got := IsDistributionId(test.value); got != test.want {
+			t.Errorf("IsDistributionId(%q): expected %t, got %t", test.value, test.want, got)
+		}
+	}
+}
+
+func TestDistributionAccountId(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "E1234567890ABC", want: ""},
+		{value: "arn:aws:cloudfront::123456789012:distribution/E1234567890ABC", want: "123456789012"},
+		{value: "arn:aws:cloudfront::123456789012:function/my-function", want: ""},
+	}
+	for _, test := range tests {
+		if got := DistributionAccountId(test.value); got != test.want {
+			t.Errorf("DistributionAccountId(%q): expected %q, got %q", test.value, test.want, got)
+		}
+	}
+}
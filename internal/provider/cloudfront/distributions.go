@@ -0,0 +1,118 @@
+package cloudfront
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"sort"
+	"strings"
+)
+
+// DistributionSelector matches distributions by their configuration and tags
+// A distribution must match every criteria that is set
+type DistributionSelector struct {
+	// Tags must all be present on the distribution with the same values
+	Tags map[string]string
+	// Alias must be one of the distribution's alternate domain names (CNAMEs), ignoring case
+	Alias string
+	// OriginDomain must be the domain name of one of the distribution's origins, ignoring case
+	OriginDomain string
+}
+
+// FindDistributionIds returns the sorted IDs of every distribution that matches the selector
+// Tags are only retrieved for distributions that match the other criteria to limit the number of API requests
+func FindDistributionIds(ctx context.Context, client *conns.Client, selector DistributionSelector) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	cfClient := client.Cloudfront()
+	ids := make([]string, 0)
+	paginator := cloudfront.NewListDistributionsPaginator(cfClient, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			diags.AddError("Error listing AWS Cloudfront Distributions", err.Error())
+			return nil, diags
+		}
+		if out.DistributionList == nil {
+			continue
+		}
+		for _, summary := range out.DistributionList.Items {
+			if !selector.MatchesSummary(summary) {
+				continue
+			}
+			if len(selector.Tags) > 0 {
+				tags, err := findDistributionTags(ctx, cfClient, aws.ToString(summary.ARN))
+				if err != nil {
+					diags.AddError("Error listing tags for AWS Cloudfront Distribution", err.Error())
+					return nil, diags
+				}
+				if !selector.MatchesTags(tags) {
+					continue
+				}
+			}
+			ids = append(ids, aws.ToString(summary.Id))
+		}
+	}
+	sort.Strings(ids)
+	tflog.Debug(ctx, "Resolved Cloudfront Distributions", map[string]any{"distribution_ids": ids})
+	return ids, diags
+}
+
+func findDistributionTags(ctx context.Context, cfClient *cloudfront.Client, distributionArn string) (map[string]string, error) {
+	out, err := cfClient.ListTagsForResource(ctx, &cloudfront.ListTagsForResourceInput{Resource: aws.String(distributionArn)})
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{}
+	if out.Tags != nil {
+		for _, tag := range out.Tags.Items {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return tags, nil
+}
+
+// MatchesSummary returns true if the distribution matches Alias and OriginDomain
+func (s DistributionSelector) MatchesSummary(summary cftypes.DistributionSummary) bool {
+	if s.Alias != "" {
+		if summary.Aliases == nil || !containsFold(summary.Aliases.Items, s.Alias) {
+			return false
+		}
+	}
+	if s.OriginDomain != "" {
+		if summary.Origins == nil {
+			return false
+		}
+		domains := make([]string, 0, len(summary.Origins.Items))
+		for _, origin := range summary.Origins.Items {
+			domains = append(domains, aws.ToString(origin.DomainName))
+		}
+		if !containsFold(domains, s.OriginDomain) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesTags returns true if every tag in Tags is present in tags with the same value
+func (s DistributionSelector) MatchesTags(tags map[string]string) bool {
+	for key, value := range s.Tags {
+		if cur, ok := tags[key]; !ok || cur != value {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package cloudfront
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"testing"
+)
+
+func TestDistributionSelector(t *testing.T) {
+	summary := cftypes.DistributionSummary{
+		Id:      aws.String("E1234567890ABC"),
+		Aliases: &cftypes.Aliases{Items: []string{"www.example.com", "example.com"}},
+		Origins: &cftypes.Origins{Items: []cftypes.Origin{
+			{DomainName: aws.String("my-bucket.s3.us-east-1.amazonaws.com")},
+		}},
+	}
+	tags := map[string]string{"env": "prod", "team": "web"}
+
+	tests := []struct {
+		name     string
+		selector DistributionSelector
+		want     bool
+	}{
+		{
+			name:     "empty selector",
+			selector: DistributionSelector{},
+			want:     true,
+		},
+		{
+			name:     "alias ignores case",
+			selector: DistributionSelector{Alias: "WWW.example.com"},
+			want:     true,
+		},
+		{
+			name:     "alias mismatch",
+			selector: DistributionSelector{Alias: "api.example.com"},
+			want:     false,
+		},
+		{
+			name:     "origin domain",
+			selector: DistributionSelector{OriginDomain: "my-bucket.s3.us-east-1.amazonaws.com"},
+			want:     true,
+		},
+		{
+			name:     "origin domain mismatch",
+			selector: DistributionSelector{OriginDomain: "other-bucket.s3.us-east-1.amazonaws.com"},
+			want:     false,
+		},
+		{
+			name:     "tags subset",
+			selector: DistributionSelector{Tags: map[string]string{"env": "prod"}},
+			want:     true,
+		},
+		{
+			name:     "tag value mismatch",
+			selector: DistributionSelector{Tags: map[string]string{"env": "dev"}},
+			want:     false,
+		},
+		{
+			name:     "all criteria must match",
+			selector: DistributionSelector{Alias: "example.com", Tags: map[string]string{"missing": "tag"}},
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.selector.MatchesSummary(summary) && test.selector.MatchesTags(tags)
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cloudfront
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FreePathsPerMonth is the number of invalidation paths that CloudFront does not bill each month
+const FreePathsPerMonth = 1000
+
+// Impact summarizes the invalidations that will be submitted to CloudFront
+type Impact struct {
+	// Distributions is the number of distributions that will be invalidated
+	Distributions int
+	// Paths is the number of distinct paths that will be submitted
+	Paths int
+	// WildcardPaths is the number of distinct wildcard paths that will be submitted
+	WildcardPaths int
+	// BillablePaths is the number of paths CloudFront bills, counting each path once per distribution
+	// A wildcard path is billed as a single path regardless of how many objects it matches
+	BillablePaths int
+}
+
+// EstimateImpact summarizes the invalidations for paths indexed by distribution ID
+// The effective paths are used so that the estimate reflects normalization and collapsing (see EffectivePaths)
+func EstimateImpact(paths map[string][]string, collapseThreshold int) Impact {
+	impact := Impact{Distributions: len(paths)}
+	distinct := map[string]bool{}
+	for _, cur := range paths {
+		effective := EffectivePaths(cur, collapseThreshold)
+		impact.BillablePaths += len(effective)
+		for _, path := range effective {
+			distinct[path] = true
+		}
+	}
+	impact.Paths = len(distinct)
+	for path := range distinct {
+		if strings.HasSuffix(path, Wildcard) {
+			impact.WildcardPaths++
+		}
+	}
+	return impact
+}
+
+func (i Impact) String() string {
+	return fmt.Sprintf("%s (%s) will be invalidated on %s for an estimated %s. "+
+		"CloudFront bills invalidation paths beyond the first %d each month.",
+		pluralize(i.Paths, "path"), pluralize(i.WildcardPaths, "wildcard path"),
+		pluralize(i.Distributions, "distribution"), pluralize(i.BillablePaths, "billable path"), FreePathsPerMonth)
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,29 @@
+package cloudfront
+
+import (
+	"testing"
+)
+
+func TestEstimateImpact(t *testing.T) {
+	paths := map[string][]string{
+		"E1": {"/*"},
+		"E2": {"/*"},
+		"E3": {"/index.html", "/assets/*"},
+	}
+	got := EstimateImpact(paths, 0)
+	want := Impact{Distributions: 3, Paths: 3, WildcardPaths: 2, BillablePaths: 4}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	wantMessage := "3 paths (2 wildcard paths) will be invalidated on 3 distributions for an estimated 4 billable paths. " +
+		"CloudFront bills invalidation paths beyond the first 1000 each month."
+	if got.String() != wantMessage {
+		t.Errorf("expected %q, got %q", wantMessage, got.String())
+	}
+
+	collapsed := EstimateImpact(map[string][]string{"E1": {"/a/1", "/a/2", "/a/3"}}, 2)
+	if collapsed.BillablePaths != 1 || collapsed.WildcardPaths != 1 {
+		t.Errorf("expected collapsed paths to be estimated, got %+v", collapsed)
+	}
+}
@@ -2,32 +2,114 @@ package cloudfront
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
 	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"sort"
+	"strings"
 	"time"
 )
 
-func CreateInvalidation(ctx context.Context, client *conns.Client, distributionId string, paths []string, createTimeout time.Duration) (*cftypes.Invalidation, diag.Diagnostics) {
+const (
+	StatusCompleted  = "Completed"
+	StatusInProgress = "InProgress"
+	// StatusFailed is recorded by this provider when an invalidation could not be created
+	StatusFailed = "Failed"
+	// StatusSkipped is recorded by this provider when invalidations are skipped (see conns.Client.SkipInvalidations)
+	StatusSkipped = "Skipped"
+	// StatusNotFound is recorded by this provider when a distribution has no invalidation in CloudFront
+	StatusNotFound = "NotFound"
+)
+
+// skippedIdPrefix prefixes the ID of an invalidation that was skipped
+// CloudFront invalidation IDs only contain uppercase letters and digits, so this never matches a real invalidation
+const skippedIdPrefix = "skipped-"
+
+// CreateOptions configures how invalidations are created
+type CreateOptions struct {
+	// CreateTimeout is the maximum amount of time to spend creating (and waiting for) every invalidation
+	CreateTimeout time.Duration
+	// WaitForCompletion causes creation to block until every invalidation has completed
+	// Otherwise, creation returns as soon as CloudFront accepts every invalidation
+	WaitForCompletion bool
+	// CallerReference overrides the caller reference of the invalidation
+	// If empty, a deterministic caller reference is generated (see CallerReference)
+	CallerReference string
+	// Triggers are included in the generated caller reference
+	Triggers map[string]string
+	// MaxConcurrency is the maximum number of distributions to invalidate at once (see CreateInvalidations)
+	MaxConcurrency int
+	// CollapseThreshold collapses paths that share a directory into a wildcard if positive (see CollapsePaths)
+	CollapseThreshold int
+}
+
+// CreateInvalidation creates the invalidations for paths on a single distribution, one batch at a time (see BatchPaths)
+func CreateInvalidation(ctx context.Context, client *conns.Client, distributionId string, paths []string, opts CreateOptions) ([]*cftypes.Invalidation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	deadline := time.Now().Add(opts.CreateTimeout)
+	effectivePaths := EffectivePaths(paths, opts.CollapseThreshold)
+	callerReference := opts.CallerReference
+	if callerReference == "" {
+		// The submitted paths depend on the collapse threshold, so they are hashed instead of the configured paths
+		callerReference = CallerReference(distributionId, effectivePaths, opts.Triggers)
+	}
+	if client.SkipInvalidations {
+		tflog.Info(ctx, "Skipping Cloudfront Invalidation", map[string]any{"distribution_id": distributionId})
+		return []*cftypes.Invalidation{SkippedInvalidation(callerReference, effectivePaths)}, diags
+	}
+	batches := BatchPaths(effectivePaths)
+	cfClient := client.Cloudfront()
+	invals := make([]*cftypes.Invalidation, 0, len(batches))
+	for i, batch := range batches {
+		if len(batches) > 1 {
+			tflog.Debug(ctx, "Creating Cloudfront Invalidation batch", map[string]any{
+				"distribution_id": distributionId,
+				"batch":           i + 1,
+				"batches":         len(batches),
+				"paths":           len(batch),
+			})
+		}
+		batchCallerReference := callerReference
+		if i > 0 {
+			batchCallerReference = fmt.Sprintf("%s-%d", callerReference, i+1)
+		}
+		inval, batchDiags := createInvalidationBatch(ctx, cfClient, distributionId, batchCallerReference, batch, deadline, opts.WaitForCompletion)
+		diags.Append(batchDiags...)
+		if inval != nil {
+			invals = append(invals, inval)
+		}
+		if inval == nil || diags.HasError() {
+			break
+		}
+	}
+	return invals, diags
+}
+
+func createInvalidationBatch(ctx context.Context, cfClient *cloudfront.Client, distributionId string, callerReference string, paths []string,
+	deadline time.Time, waitForCompletion bool) (*cftypes.Invalidation, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	input := &cloudfront.CreateInvalidationInput{
 		DistributionId: &distributionId,
 		InvalidationBatch: &cftypes.InvalidationBatch{
-			CallerReference: aws.String(uuid.NewString()),
+			CallerReference: aws.String(callerReference),
 			Paths: &cftypes.Paths{
 				Quantity: aws.Int32(int32(len(paths))),
 				Items:    paths,
 			},
 		},
 	}
-	cfClient := client.Cloudfront()
-	out, err := cfClient.CreateInvalidation(ctx, input)
+	out, err := createInvalidationWithRetry(ctx, cfClient, input, deadline)
 	if err != nil {
 		diags.AddError("Error creating AWS Cloudfront Invalidation", err.Error())
 		return nil, diags
@@ -38,12 +120,16 @@ func CreateInvalidation(ctx context.Context, client *conns.Client, distributionI
 		diags.AddWarning("Unable to create AWS Cloudfront Invalidation.", "AWS did not create an invalidation and gave no reason")
 		return nil, diags
 	}
+	if !waitForCompletion {
+		// When batching, the next batch will be retried until this one completes and frees up the quota
+		return out.Invalidation, diags
+	}
 
 	waiter := cloudfront.NewInvalidationCompletedWaiter(cfClient)
 	res, err := waiter.WaitForOutput(ctx, &cloudfront.GetInvalidationInput{
 		DistributionId: aws.String(distributionId),
 		Id:             out.Invalidation.Id,
-	}, createTimeout)
+	}, time.Until(deadline))
 	if err != nil {
 		diags.AddError("Error waiting for creation of AWS Cloudfront Invalidation", err.Error())
 		return out.Invalidation, diags
@@ -54,9 +140,82 @@ func CreateInvalidation(ctx context.Context, client *conns.Client, distributionI
 	return out.Invalidation, diags
 }
 
+const (
+	// TriggerAlwaysInvalidate is added to the triggers of an invalidation that is repeated on every apply
+	// Its value is the ID of the previous invalidation so that each apply uses a new caller reference
+	TriggerAlwaysInvalidate = "awsex:always_invalidate"
+	// TriggerInvalidateOnDestroy is added to the triggers of an invalidation that is created when a resource is destroyed
+	// Its value is the ID of the resource's invalidation so that it does not reuse the resource's caller reference
+	TriggerInvalidateOnDestroy = "awsex:invalidate_on_destroy"
+	// TriggerCreateNonce is added to the triggers of the invalidations created with a resource
+	// Its value is generated when the resource is created so that a replaced resource does not reuse the previous caller reference
+	TriggerCreateNonce = "awsex:create_nonce"
+)
+
+// WithTrigger returns a copy of triggers with an additional trigger
+func WithTrigger(triggers map[string]string, key string, value string) map[string]string {
+	result := make(map[string]string, len(triggers)+1)
+	for k, v := range triggers {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+// CallerReference generates a deterministic caller reference for an invalidation
+// It is a hash of the distribution ID, the sorted normalized paths, and the triggers
+// CreateInvalidation passes the effective paths, so changing the collapse threshold changes the caller reference
+func CallerReference(distributionId string, paths []string, triggers map[string]string) string {
+	normalized := NormalizePaths(paths)
+	sort.Strings(normalized)
+	// json.Marshal sorts map keys, so the encoding is stable
+	raw, _ := json.Marshal(struct {
+		DistributionId string            `json:"distribution_id"`
+		Paths          []string          `json:"paths"`
+		Triggers       map[string]string `json:"triggers"`
+	}{
+		DistributionId: distributionId,
+		Paths:          normalized,
+		Triggers:       triggers,
+	})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// SkippedInvalidation synthesizes the result of an invalidation that was skipped, with an ID derived from callerReference
+func SkippedInvalidation(callerReference string, paths []string) *cftypes.Invalidation {
+	id := callerReference
+	if len(id) > 16 {
+		id = id[:16]
+	}
+	return &cftypes.Invalidation{
+		Id:         aws.String(skippedIdPrefix + id),
+		Status:     aws.String(StatusSkipped),
+		CreateTime: aws.Time(time.Now().UTC()),
+		InvalidationBatch: &cftypes.InvalidationBatch{
+			CallerReference: aws.String(callerReference),
+			Paths: &cftypes.Paths{
+				Quantity: aws.Int32(int32(len(paths))),
+				Items:    paths,
+			},
+		},
+	}
+}
+
+// IsSkippedInvalidationId returns true if id was synthesized by SkippedInvalidation
+func IsSkippedInvalidationId(id string) bool {
+	return strings.HasPrefix(id, skippedIdPrefix)
+}
+
+// FindInvalidation finds an invalidation by ID
+// A skipped invalidation does not exist in CloudFront, so it is returned without its paths
 func FindInvalidation(ctx context.Context, client *conns.Client, distributionId string, id string) (*cftypes.Invalidation, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	if IsSkippedInvalidationId(id) {
+		return &cftypes.Invalidation{Id: aws.String(id), Status: aws.String(StatusSkipped)}, diags
+	}
+
 	input := &cloudfront.GetInvalidationInput{
 		DistributionId: &distributionId,
 		Id:             &id,
@@ -73,3 +232,78 @@ func FindInvalidation(ctx context.Context, client *conns.Client, distributionId
 	}
 	return nil, diags
 }
+
+// FindInvalidationBatches finds the invalidations of a single distribution, omitting those that no longer exist
+func FindInvalidationBatches(ctx context.Context, client *conns.Client, distributionId string, ids []string) ([]*cftypes.Invalidation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	invals := make([]*cftypes.Invalidation, 0, len(ids))
+	for _, id := range ids {
+		inval, findDiags := FindInvalidation(ctx, client, distributionId, id)
+		diags.Append(findDiags...)
+		if inval != nil {
+			invals = append(invals, inval)
+		}
+	}
+	return invals, diags
+}
+
+// InvalidationIds returns the ID of each invalidation
+func InvalidationIds(invals []*cftypes.Invalidation) []string {
+	ids := make([]string, 0, len(invals))
+	for _, inval := range invals {
+		ids = append(ids, aws.ToString(inval.Id))
+	}
+	return ids
+}
+
+// InvalidationPaths returns the paths from every invalidation's batch
+func InvalidationPaths(invals []*cftypes.Invalidation) []string {
+	paths := make([]string, 0)
+	for _, inval := range invals {
+		if inval.InvalidationBatch != nil && inval.InvalidationBatch.Paths != nil {
+			paths = append(paths, inval.InvalidationBatch.Paths.Items...)
+		}
+	}
+	return paths
+}
+
+// InvalidationArn synthesizes an ARN for an invalidation
+// CloudFront does not assign ARNs to invalidations, so this extends the ARN format of the distribution
+// If the ARN cannot be determined, nil is returned with a warning
+func InvalidationArn(ctx context.Context, client *conns.Client, distributionId string, id string) (*string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// A skipped invalidation does not exist, so it has no ARN
+	if IsSkippedInvalidationId(id) {
+		return nil, diags
+	}
+
+	accountId, partition, accountDiags := client.AccountIdAndPartition(ctx)
+	if accountDiags.HasError() {
+		for _, d := range accountDiags.Errors() {
+			diags.AddWarning("Unable to determine ARN of AWS Cloudfront Invalidation", d.Summary()+": "+d.Detail())
+		}
+		return nil, diags
+	}
+
+	return aws.String(arn.ARN{
+		Partition: partition,
+		Service:   "cloudfront",
+		AccountID: accountId,
+		Resource:  fmt.Sprintf("distribution/%s/invalidation/%s", distributionId, id),
+	}.String()), diags
+}
+
+// AggregateStatus returns the status of the first invalidation that has not completed, or StatusCompleted
+func AggregateStatus(invals []*cftypes.Invalidation) string {
+	if len(invals) == 0 {
+		return ""
+	}
+	for _, inval := range invals {
+		if status := aws.ToString(inval.Status); status != StatusCompleted {
+			return status
+		}
+	}
+	return StatusCompleted
+}
@@ -0,0 +1,75 @@
+package cloudfront
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"testing"
+)
+
+func TestCallerReference(t *testing.T) {
+	base := CallerReference("E2QWRUHAPOMQZL", []string{"/a", "/b c"}, map[string]string{"version": "1"})
+	if got := CallerReference("E2QWRUHAPOMQZL", []string{"/b%20c", "/a"}, map[string]string{"version": "1"}); got != base {
+		t.Errorf("expected caller reference to ignore path order and normalization, got %q, want %q", got, base)
+	}
+	if got := CallerReference("E2QWRUHAPOMQZM", []string{"/a", "/b c"}, map[string]string{"version": "1"}); got == base {
+		t.Error("expected caller reference to change with distribution ID")
+	}
+	if got := CallerReference("E2QWRUHAPOMQZL", []string{"/a"}, map[string]string{"version": "1"}); got == base {
+		t.Error("expected caller reference to change with paths")
+	}
+	if got := CallerReference("E2QWRUHAPOMQZL", []string{"/a", "/b c"}, map[string]string{"version": "2"}); got == base {
+		t.Error("expected caller reference to change with triggers")
+	}
+}
+
+func TestCreateInvalidationCallerReferenceCollapse(t *testing.T) {
+	ctx := context.Background()
+	client := &conns.Client{SkipInvalidations: true}
+	paths := []string{"/a/1", "/a/2", "/a/3"}
+	callerReference := func(collapseThreshold int) string {
+		invals, diags := CreateInvalidation(ctx, client, "E2QWRUHAPOMQZL", paths, CreateOptions{CollapseThreshold: collapseThreshold})
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return aws.ToString(invals[0].InvalidationBatch.CallerReference)
+	}
+	if callerReference(2) == callerReference(0) {
+		t.Error("expected caller reference to change when the paths are collapsed")
+	}
+	if callerReference(0) != CallerReference("E2QWRUHAPOMQZL", paths, nil) {
+		t.Error("expected caller reference of paths that are not collapsed to hash the paths")
+	}
+}
+
+func TestCreateInvalidationSkipped(t *testing.T) {
+	ctx := context.Background()
+	client := &conns.Client{SkipInvalidations: true}
+	invals, diags := CreateInvalidation(ctx, client, "E2QWRUHAPOMQZL", []string{"/a", "/b c"}, CreateOptions{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(invals) != 1 {
+		t.Fatalf("expected a single skipped invalidation, got %d", len(invals))
+	}
+	id := aws.ToString(invals[0].Id)
+	if !IsSkippedInvalidationId(id) {
+		t.Errorf("expected a skipped invalidation ID, got %q", id)
+	}
+	if status := AggregateStatus(invals); status != StatusSkipped {
+		t.Errorf("expected status %q, got %q", StatusSkipped, status)
+	}
+	if paths := InvalidationPaths(invals); len(paths) != 2 || paths[1] != "/b%20c" {
+		t.Errorf("expected the effective paths to be recorded, got %v", paths)
+	}
+
+	again, _ := CreateInvalidation(ctx, client, "E2QWRUHAPOMQZL", []string{"/a", "/b c"}, CreateOptions{})
+	if got := aws.ToString(again[0].Id); got != id {
+		t.Errorf("expected a stable ID for a retried apply, got %q, want %q", got, id)
+	}
+
+	found, diags := FindInvalidation(ctx, client, "E2QWRUHAPOMQZL", id)
+	if diags.HasError() || found == nil || aws.ToString(found.Status) != StatusSkipped {
+		t.Errorf("expected to find the skipped invalidation without calling CloudFront, got %v (%v)", found, diags)
+	}
+}
@@ -2,68 +2,121 @@ package cloudfront
 
 import (
 	"context"
+	"fmt"
 	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"sort"
 	"sync"
 	"time"
 )
 
-type invalidationResult struct {
+// InvalidationResult is the result of creating or finding the invalidations for a single distribution
+type InvalidationResult struct {
 	DistributionId string
-	Invalidation   *cftypes.Invalidation
+	Invalidations  []*cftypes.Invalidation
 	Diags          diag.Diagnostics
 }
 
-func CreateInvalidations(ctx context.Context, client *conns.Client, distributionIds []string, paths []string,
-	createTimeout time.Duration) (map[string]*cftypes.Invalidation, diag.Diagnostics) {
-	ch := make(chan invalidationResult, len(distributionIds))
+// Failed returns true if creating the invalidations for the distribution failed
+// A failed result may still contain the invalidations for batches that were created before the failure
+func (r InvalidationResult) Failed() bool {
+	return r.Diags.HasError()
+}
 
-	var wg sync.WaitGroup
-	for _, distributionId := range distributionIds {
-		wg.Add(1)
-		go func(distributionId string) {
-			defer wg.Done()
-			inval, diags := CreateInvalidation(ctx, client, distributionId, paths, createTimeout)
-			ch <- invalidationResult{
-				DistributionId: distributionId,
-				Invalidation:   inval,
-				Diags:          diags,
-			}
+// Clients contains the client used to manage the invalidations of each distribution
+// Distributions without a client in Distributions (e.g. distributions in the provider's account) use Default
+// Failed contains the errors for distributions whose client could not be created, e.g. when a role could not be assumed
+type Clients struct {
+	Default       *conns.Client
+	Distributions map[string]*conns.Client
+	Failed        map[string]diag.Diagnostics
+}
 
-		}(distributionId)
+// For returns the client for a distribution
+func (c Clients) For(distributionId string) *conns.Client {
+	if client, ok := c.Distributions[distributionId]; ok {
+		return client
 	}
+	return c.Default
+}
 
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
+// CreateInvalidations invalidates the paths of each distribution concurrently; a failure does not stop the others
+func CreateInvalidations(ctx context.Context, clients Clients, paths map[string][]string,
+	opts CreateOptions) (map[string]InvalidationResult, diag.Diagnostics) {
+	distributionIds := make([]string, 0, len(paths))
+	for distributionId := range paths {
+		distributionIds = append(distributionIds, distributionId)
+	}
+	// Distributions that are queued behind others must still finish within the original timeout
+	deadline := time.Now().Add(opts.CreateTimeout)
+	results := forEachDistribution(distributionIds, resolveMaxConcurrency(clients.Default, opts.MaxConcurrency), func(distributionId string) InvalidationResult {
+		if diags, ok := clients.Failed[distributionId]; ok {
+			return InvalidationResult{DistributionId: distributionId, Diags: diags}
+		}
+		distributionOpts := opts
+		distributionOpts.CreateTimeout = time.Until(deadline)
+		invals, diags := CreateInvalidation(ctx, clients.For(distributionId), distributionId, paths[distributionId], distributionOpts)
+		return InvalidationResult{
+			DistributionId: distributionId,
+			Invalidations:  invals,
+			Diags:          diags,
+		}
+	})
 
-	results := make(map[string]*cftypes.Invalidation)
 	var diags diag.Diagnostics
-	for cur := range ch {
-		results[cur.DistributionId] = cur.Invalidation
-		diags.Append(cur.Diags...)
+	for distributionId, cur := range results {
+		diags.Append(withDistributionId(distributionId, cur.Diags)...)
+	}
+	return results, diags
+}
+
+// FindInvalidations finds the invalidations for every distribution concurrently
+func FindInvalidations(ctx context.Context, clients Clients, ids map[string][]string, maxConcurrency int) (map[string][]*cftypes.Invalidation, diag.Diagnostics) {
+	distributionIds := make([]string, 0, len(ids))
+	for distributionId := range ids {
+		distributionIds = append(distributionIds, distributionId)
+	}
+	found := forEachDistribution(distributionIds, resolveMaxConcurrency(clients.Default, maxConcurrency), func(distributionId string) InvalidationResult {
+		// Distributions that were never invalidated can be refreshed without a client
+		if diags, ok := clients.Failed[distributionId]; ok && len(ids[distributionId]) > 0 {
+			return InvalidationResult{DistributionId: distributionId, Diags: diags}
+		}
+		invals, diags := FindInvalidationBatches(ctx, clients.For(distributionId), distributionId, ids[distributionId])
+		return InvalidationResult{
+			DistributionId: distributionId,
+			Invalidations:  invals,
+			Diags:          diags,
+		}
+	})
+
+	results := make(map[string][]*cftypes.Invalidation)
+	var diags diag.Diagnostics
+	for distributionId, cur := range found {
+		results[distributionId] = cur.Invalidations
+		diags.Append(withDistributionId(distributionId, cur.Diags)...)
 	}
 	return results, diags
 }
 
-func FindInvalidations(ctx context.Context, client *conns.Client, ids map[string]string) (map[string]*cftypes.Invalidation, diag.Diagnostics) {
-	ch := make(chan invalidationResult, len(ids))
+// forEachDistribution calls fn for every distribution using a pool of maxConcurrency workers
+func forEachDistribution(distributionIds []string, maxConcurrency int, fn func(distributionId string) InvalidationResult) map[string]InvalidationResult {
+	jobs := make(chan string, len(distributionIds))
+	for _, distributionId := range distributionIds {
+		jobs <- distributionId
+	}
+	close(jobs)
 
+	ch := make(chan InvalidationResult, len(distributionIds))
 	var wg sync.WaitGroup
-	for distributionId, id := range ids {
+	for i := 0; i < maxConcurrency && i < len(distributionIds); i++ {
 		wg.Add(1)
-		go func(distributionId, id string) {
+		go func() {
 			defer wg.Done()
-			inval, diags := FindInvalidation(ctx, client, distributionId, id)
-			ch <- invalidationResult{
-				DistributionId: distributionId,
-				Invalidation:   inval,
-				Diags:          diags,
+			for distributionId := range jobs {
+				ch <- fn(distributionId)
 			}
-
-		}(distributionId, id)
+		}()
 	}
 
 	go func() {
@@ -71,11 +124,46 @@ func FindInvalidations(ctx context.Context, client *conns.Client, ids map[string
 		close(ch)
 	}()
 
-	results := make(map[string]*cftypes.Invalidation)
-	var diags diag.Diagnostics
+	results := make(map[string]InvalidationResult)
 	for cur := range ch {
-		results[cur.DistributionId] = cur.Invalidation
-		diags.Append(cur.Diags...)
+		results[cur.DistributionId] = cur
 	}
-	return results, diags
+	return results
+}
+
+// resolveMaxConcurrency returns the first of configured, the provider's max_concurrency, or conns.DefaultMaxConcurrency that is set
+func resolveMaxConcurrency(client *conns.Client, configured int) int {
+	if configured > 0 {
+		return configured
+	}
+	if client.MaxConcurrency > 0 {
+		return client.MaxConcurrency
+	}
+	return conns.DefaultMaxConcurrency
+}
+
+// FailedDistributionIds returns the sorted IDs of the distributions whose invalidations failed
+func FailedDistributionIds(results map[string]InvalidationResult) []string {
+	failed := make([]string, 0)
+	for distributionId, result := range results {
+		if result.Failed() {
+			failed = append(failed, distributionId)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+func withDistributionId(distributionId string, in diag.Diagnostics) diag.Diagnostics {
+	var out diag.Diagnostics
+	for _, d := range in {
+		summary := fmt.Sprintf("%s (distribution %s)", d.Summary(), distributionId)
+		switch d.Severity() {
+		case diag.SeverityError:
+			out.AddError(summary, d.Detail())
+		case diag.SeverityWarning:
+			out.AddWarning(summary, d.Detail())
+		}
+	}
+	return out
 }
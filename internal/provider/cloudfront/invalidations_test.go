@@ -0,0 +1,81 @@
+package cloudfront
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront/cloudfronttest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateInvalidationsFailedClient(t *testing.T) {
+	ctx := context.Background()
+	var roleDiags diag.Diagnostics
+	roleDiags.AddError("Cannot assume IAM Role", "AccessDenied")
+	clients := Clients{
+		Default: &conns.Client{SkipInvalidations: true},
+		Failed:  map[string]diag.Diagnostics{"E2222222222222": roleDiags},
+	}
+	paths := map[string][]string{"E1111111111111": {"/*"}, "E2222222222222": {"/*"}}
+
+	results, diags := CreateInvalidations(ctx, clients, paths, CreateOptions{})
+	if got := FailedDistributionIds(results); len(got) != 1 || got[0] != "E2222222222222" {
+		t.Errorf("expected only E2222222222222 to fail, got %v", got)
+	}
+	if len(results["E1111111111111"].Invalidations) != 1 {
+		t.Errorf("expected E1111111111111 to be invalidated, got %v", results["E1111111111111"])
+	}
+	if len(diags.Errors()) != 1 || diags.Errors()[0].Summary() != "Cannot assume IAM Role (distribution E2222222222222)" {
+		t.Errorf("expected the role error for E2222222222222, got %v", diags)
+	}
+
+	found, diags := FindInvalidations(ctx, clients, map[string][]string{"E1111111111111": InvalidationIds(results["E1111111111111"].Invalidations), "E2222222222222": {}}, 0)
+	if diags.HasError() {
+		t.Errorf("expected distributions without invalidations to be refreshed without a client, got %v", diags)
+	}
+	if len(found["E1111111111111"]) != 1 {
+		t.Errorf("expected to find the invalidation for E1111111111111, got %v", found)
+	}
+}
+
+func TestCreateInvalidationsPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	server := cloudfronttest.NewServer(t)
+	server.SetError("E2222222222222", "AccessDenied")
+	clients := Clients{Default: server.Client()}
+	paths := map[string][]string{"E1111111111111": {"/*"}, "E2222222222222": {"/*"}, "E3333333333333": {"/*"}}
+	opts := CreateOptions{CreateTimeout: time.Minute, MaxConcurrency: 2}
+
+	results, diags := CreateInvalidations(ctx, clients, paths, opts)
+	if got := FailedDistributionIds(results); len(got) != 1 || got[0] != "E2222222222222" {
+		t.Fatalf("expected only E2222222222222 to fail, got %v (%v)", got, diags)
+	}
+	for _, distributionId := range []string{"E1111111111111", "E3333333333333"} {
+		if ids := InvalidationIds(results[distributionId].Invalidations); len(ids) != 1 || ids[0] != "I"+distributionId {
+			t.Errorf("expected the invalidation for %s to be saved, got %v", distributionId, ids)
+		}
+	}
+	if len(diags.Errors()) != 1 || !strings.Contains(diags.Errors()[0].Summary(), "E2222222222222") {
+		t.Errorf("expected a single error for E2222222222222, got %v", diags)
+	}
+
+	// Only the failed distribution is retried
+	server.SetError("E2222222222222", "")
+	retried, diags := CreateInvalidations(ctx, clients, map[string][]string{"E2222222222222": paths["E2222222222222"]}, opts)
+	if diags.HasError() || len(FailedDistributionIds(retried)) > 0 {
+		t.Fatalf("unexpected error retrying: %v", diags)
+	}
+	want := map[string]int{"E1111111111111": 1, "E2222222222222": 2, "E3333333333333": 1}
+	for distributionId, requests := range want {
+		if got := server.Requests(distributionId); got != requests {
+			t.Errorf("expected %d requests for %s, got %d", requests, distributionId, got)
+		}
+	}
+	callerReference := CallerReference("E2222222222222", paths["E2222222222222"], nil)
+	if inval := retried["E2222222222222"].Invalidations[0]; aws.ToString(inval.InvalidationBatch.CallerReference) != callerReference {
+		t.Errorf("expected the retry to use the deterministic caller reference %q, got %q", callerReference, aws.ToString(inval.InvalidationBatch.CallerReference))
+	}
+}
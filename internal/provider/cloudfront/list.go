@@ -0,0 +1,89 @@
+package cloudfront
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"sort"
+	"time"
+)
+
+// ListFilter restricts the invalidations returned by ListInvalidations
+// Zero values do not filter
+type ListFilter struct {
+	// Status must match the status of the invalidation (e.g. StatusInProgress)
+	Status string
+	// CreatedAfter excludes invalidations created before this time
+	CreatedAfter time.Time
+	// CreatedBefore excludes invalidations created at or after this time
+	CreatedBefore time.Time
+	// MaxResults limits the result to the most recent invalidations
+	MaxResults int
+}
+
+// ListInvalidations lists the invalidations for a distribution that match the filter, most recent first
+func ListInvalidations(ctx context.Context, client *conns.Client, distributionId string, filter ListFilter) ([]cftypes.InvalidationSummary, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	summaries := make([]cftypes.InvalidationSummary, 0)
+	paginator := cloudfront.NewListInvalidationsPaginator(client.Cloudfront(), &cloudfront.ListInvalidationsInput{
+		DistributionId: aws.String(distributionId),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			diags.AddError("Error listing AWS Cloudfront Invalidations", err.Error())
+			return nil, diags
+		}
+		if out.InvalidationList == nil {
+			continue
+		}
+		summaries = append(summaries, out.InvalidationList.Items...)
+		if listComplete(summaries, out.InvalidationList.Items, filter) {
+			break
+		}
+	}
+	return FilterInvalidationSummaries(summaries, filter), diags
+}
+
+// listComplete returns true if the remaining pages cannot change the result of the filter
+// CloudFront lists the most recent invalidations first, so later pages only contain older invalidations
+func listComplete(summaries []cftypes.InvalidationSummary, page []cftypes.InvalidationSummary, filter ListFilter) bool {
+	if len(page) > 0 && !filter.CreatedAfter.IsZero() && aws.ToTime(page[len(page)-1].CreateTime).Before(filter.CreatedAfter) {
+		return true
+	}
+	if filter.MaxResults <= 0 {
+		return false
+	}
+	unlimited := filter
+	unlimited.MaxResults = 0
+	return len(FilterInvalidationSummaries(summaries, unlimited)) >= filter.MaxResults
+}
+
+// FilterInvalidationSummaries returns the summaries that match the filter sorted by create time, most recent first
+func FilterInvalidationSummaries(summaries []cftypes.InvalidationSummary, filter ListFilter) []cftypes.InvalidationSummary {
+	filtered := make([]cftypes.InvalidationSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		if filter.Status != "" && aws.ToString(summary.Status) != filter.Status {
+			continue
+		}
+		createTime := aws.ToTime(summary.CreateTime)
+		if !filter.CreatedAfter.IsZero() && createTime.Before(filter.CreatedAfter) {
+			continue
+		}
+		if !filter.CreatedBefore.IsZero() && !createTime.Before(filter.CreatedBefore) {
+			continue
+		}
+		filtered = append(filtered, summary)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return aws.ToTime(filtered[i].CreateTime).After(aws.ToTime(filtered[j].CreateTime))
+	})
+	if filter.MaxResults > 0 && len(filtered) > filter.MaxResults {
+		filtered = filtered[:filter.MaxResults]
+	}
+	return filtered
+}
@@ -0,0 +1,136 @@
+package cloudfront
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront/cloudfronttest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFilterInvalidationSummaries(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	summaries := []cftypes.InvalidationSummary{
+		{Id: aws.String("I1"), Status: aws.String(StatusCompleted), CreateTime: aws.Time(now.Add(-3 * time.Hour))},
+		{Id: aws.String("I2"), Status: aws.String(StatusInProgress), CreateTime: aws.Time(now.Add(-time.Hour))},
+		{Id: aws.String("I3"), Status: aws.String(StatusCompleted), CreateTime: aws.Time(now.Add(-2 * time.Hour))},
+	}
+
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   []string
+	}{
+		{
+			name:   "no filter sorts most recent first",
+			filter: ListFilter{},
+			want:   []string{"I2", "I3", "I1"},
+		},
+		{
+			name:   "status",
+			filter: ListFilter{Status: StatusCompleted},
+			want:   []string{"I3", "I1"},
+		},
+		{
+			name:   "created after",
+			filter: ListFilter{CreatedAfter: now.Add(-2 * time.Hour)},
+			want:   []string{"I2", "I3"},
+		},
+		{
+			name:   "created before",
+			filter: ListFilter{CreatedBefore: now.Add(-2 * time.Hour)},
+			want:   []string{"I1"},
+		},
+		{
+			name:   "max results",
+			filter: ListFilter{MaxResults: 1},
+			want:   []string{"I2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, summary := range FilterInvalidationSummaries(summaries, test.filter) {
+				got = append(got, aws.ToString(summary.Id))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestListInvalidationsStopsPaging(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	summaries := make([]cftypes.InvalidationSummary, 0)
+	for i := 0; i < 10; i++ {
+		status := StatusCompleted
+		if i%2 == 0 {
+			status = StatusInProgress
+		}
+		summaries = append(summaries, cftypes.InvalidationSummary{
+			Id:         aws.String(fmt.Sprintf("I%d", i)),
+			Status:     aws.String(status),
+			CreateTime: aws.Time(now.Add(-time.Duration(i) * time.Hour)),
+		})
+	}
+	server := cloudfronttest.NewServer(t)
+	server.SetSummaries("E1111111111111", summaries)
+
+	tests := []struct {
+		name         string
+		filter       ListFilter
+		want         []string
+		wantRequests int
+	}{
+		{
+			name:         "no filter lists every page",
+			filter:       ListFilter{},
+			want:         []string{"I0", "I1", "I2", "I3", "I4", "I5", "I6", "I7", "I8", "I9"},
+			wantRequests: 5,
+		},
+		{
+			name:         "max results",
+			filter:       ListFilter{MaxResults: 3},
+			want:         []string{"I0", "I1", "I2"},
+			wantRequests: 2,
+		},
+		{
+			name:         "max results with status",
+			filter:       ListFilter{MaxResults: 3, Status: StatusCompleted},
+			want:         []string{"I1", "I3", "I5"},
+			wantRequests: 3,
+		},
+		{
+			name:         "created after",
+			filter:       ListFilter{CreatedAfter: now.Add(-4 * time.Hour)},
+			want:         []string{"I0", "I1", "I2", "I3", "I4"},
+			wantRequests: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := server.ListRequests("E1111111111111")
+			found, diags := ListInvalidations(ctx, server.Client(), "E1111111111111", test.filter)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			got := make([]string, 0)
+			for _, summary := range found {
+				got = append(got, aws.ToString(summary.Id))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+			if requests := server.ListRequests("E1111111111111") - before; requests != test.wantRequests {
+				t.Errorf("expected %d requests, got %d", test.wantRequests, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cloudfront
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ManifestChanges returns the sorted paths whose content hash differs between two manifests
+// A manifest maps each URL path to a hash of its content
+// Paths that were added or removed are included as well as paths whose hash changed
+func ManifestChanges(previous, current map[string]string) []string {
+	changed := make([]string, 0)
+	for path, hash := range current {
+		if prev, ok := previous[path]; !ok || prev != hash {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// ManifestTriggers returns the triggers used to generate the caller reference when invalidating manifest changes
+// The new hash of each changed path is included so that different changes to the same paths use a different caller reference
+// previousIds are the IDs of the invalidations for the previous change so that repeating a change (e.g. a rollback)
+// still creates a new invalidation while retrying the same change does not
+func ManifestTriggers(current map[string]string, changed []string, previousIds []string) map[string]string {
+	triggers := map[string]string{}
+	for _, path := range changed {
+		// Removed paths have an empty hash
+		triggers[path] = current[path]
+	}
+	for i, id := range previousIds {
+		triggers[fmt.Sprintf("previous_invalidation_id.%d", i)] = id
+	}
+	return triggers
+}
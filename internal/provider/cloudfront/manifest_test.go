@@ -0,0 +1,69 @@
+package cloudfront
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManifestChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous map[string]string
+		current  map[string]string
+		want     []string
+	}{
+		{
+			name:     "unchanged",
+			previous: map[string]string{"/index.html": "a", "/app.js": "b"},
+			current:  map[string]string{"/index.html": "a", "/app.js": "b"},
+			want:     []string{},
+		},
+		{
+			name:     "empty previous",
+			previous: nil,
+			current:  map[string]string{"/index.html": "a", "/app.js": "b"},
+			want:     []string{"/app.js", "/index.html"},
+		},
+		{
+			name:     "changed, added and removed",
+			previous: map[string]string{"/index.html": "a", "/app.js": "b", "/old.css": "c"},
+			current:  map[string]string{"/index.html": "a", "/app.js": "B", "/new.css": "d"},
+			want:     []string{"/app.js", "/new.css", "/old.css"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ManifestChanges(test.previous, test.current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestManifestTriggers(t *testing.T) {
+	previous := map[string]string{"/index.html": "a"}
+	current := map[string]string{"/index.html": "b"}
+	changed := ManifestChanges(previous, current)
+
+	forward := CallerReference("E1", changed, ManifestTriggers(current, changed, []string{"I1"}))
+	if retried := CallerReference("E1", changed, ManifestTriggers(current, changed, []string{"I1"})); retried != forward {
+		t.Errorf("expected retried change to use the same caller reference")
+	}
+	if repeated := CallerReference("E1", changed, ManifestTriggers(current, changed, []string{"I3"})); repeated == forward {
+		t.Errorf("expected repeated change to use a different caller reference")
+	}
+	rollback := ManifestChanges(current, previous)
+	if back := CallerReference("E1", rollback, ManifestTriggers(previous, rollback, []string{"I1"})); back == forward {
+		t.Errorf("expected rollback to use a different caller reference")
+	}
+
+	created := ManifestChanges(nil, current)
+	if retried := CallerReference("E1", created, ManifestTriggers(current, created, nil)); retried != CallerReference("E1", created, ManifestTriggers(current, created, nil)) {
+		t.Errorf("expected retried create to use the same caller reference")
+	}
+	if other := ManifestChanges(nil, previous); CallerReference("E1", other, ManifestTriggers(previous, other, nil)) == CallerReference("E1", created, ManifestTriggers(current, created, nil)) {
+		t.Errorf("expected create with different content to use a different caller reference")
+	}
+}
@@ -0,0 +1,205 @@
+package cloudfront
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxPathLength is the maximum length of a single invalidation path accepted by CloudFront
+	MaxPathLength = 4000
+	// Wildcard may only appear as the last character of an invalidation path
+	Wildcard = "*"
+	// MaxPathsPerBatch is the maximum number of paths that can be in progress for a distribution at one time
+	MaxPathsPerBatch = 3000
+	// MaxWildcardPathsPerBatch is the maximum number of wildcard paths that can be in progress for a distribution at one time
+	MaxWildcardPathsPerBatch = 15
+)
+
+// unsafeChars are the characters defined as unsafe by RFC 1738
+// CloudFront requires these to be URL-encoded in invalidation paths
+const unsafeChars = " <>\"#{}|\\^~[]`"
+
+// ValidatePath checks a single invalidation path against the rules enforced by CloudFront
+// It must:
+// * Start with `/`
+// * Be no longer than MaxPathLength characters once normalized
+// * Only contain a wildcard as the last character
+// * Only contain well-formed percent-encoded sequences
+func ValidatePath(path string) []error {
+	errs := make([]error, 0)
+	if !strings.HasPrefix(path, "/") {
+		errs = append(errs, fmt.Errorf("must start with %q", "/"))
+	}
+	if idx := strings.Index(path, Wildcard); idx >= 0 && idx != len(path)-1 {
+		errs = append(errs, fmt.Errorf("wildcard %q is only allowed as the last character", Wildcard))
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '%' {
+			continue
+		}
+		if i+2 >= len(path) || !isHex(path[i+1]) || !isHex(path[i+2]) {
+			errs = append(errs, fmt.Errorf("contains an invalid URL-encoded sequence at position %d", i))
+			break
+		}
+	}
+	if n := len(NormalizePath(path)); n > MaxPathLength {
+		errs = append(errs, fmt.Errorf("must be at most %d characters once URL-encoded, got %d", MaxPathLength, n))
+	}
+	return errs
+}
+
+// NormalizePath URL-encodes the characters in an invalidation path that CloudFront requires to be encoded
+// These are control characters, non-ASCII characters, and the unsafe characters defined in RFC 1738
+// Any other characters are left as-is; otherwise CloudFront will not invalidate the cached object
+func NormalizePath(path string) string {
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c <= 0x20 || c >= 0x7f || strings.IndexByte(unsafeChars, c) >= 0 {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// NormalizePaths applies NormalizePath to each path
+func NormalizePaths(paths []string) []string {
+	normalized := make([]string, 0, len(paths))
+	for _, path := range paths {
+		normalized = append(normalized, NormalizePath(path))
+	}
+	return normalized
+}
+
+// EquivalentPaths returns true if both lists contain the same paths once normalized, ignoring order and duplicates
+// CloudFront reports the normalized paths, so this is used to compare configured paths with an existing invalidation
+func EquivalentPaths(a, b []string) bool {
+	setA, setB := map[string]bool{}, map[string]bool{}
+	for _, path := range a {
+		setA[NormalizePath(path)] = true
+	}
+	for _, path := range b {
+		setB[NormalizePath(path)] = true
+	}
+	if len(setA) != len(setB) {
+		return false
+	}
+	for path := range setA {
+		if !setB[path] {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// BatchPaths splits paths into batches of at most MaxPathsPerBatch paths and MaxWildcardPathsPerBatch wildcard paths
+func BatchPaths(paths []string) [][]string {
+	batches := make([][]string, 0)
+	cur := make([]string, 0)
+	wildcards := 0
+	for _, path := range paths {
+		isWildcard := strings.HasSuffix(path, Wildcard)
+		if len(cur) >= MaxPathsPerBatch || (isWildcard && wildcards >= MaxWildcardPathsPerBatch) {
+			batches = append(batches, cur)
+			cur = make([]string, 0)
+			wildcards = 0
+		}
+		cur = append(cur, path)
+		if isWildcard {
+			wildcards++
+		}
+	}
+	if len(cur) > 0 || len(batches) == 0 {
+		batches = append(batches, cur)
+	}
+	return batches
+}
+
+// EffectivePaths returns the paths that are submitted to CloudFront
+// Paths are normalized and, if collapseThreshold is positive, collapsed (see CollapsePaths)
+func EffectivePaths(paths []string, collapseThreshold int) []string {
+	normalized := NormalizePaths(paths)
+	if collapseThreshold <= 0 {
+		return normalized
+	}
+	return CollapsePaths(normalized, collapseThreshold)
+}
+
+// CollapsePaths minimizes the number of billable paths
+// CloudFront bills a wildcard path as a single path, so when more than threshold paths share a directory,
+// they are replaced with a wildcard for the directory (e.g. `/assets/*`)
+// Collapsing repeats up the directory tree, and paths already covered by a wildcard are removed
+// The result is sorted
+func CollapsePaths(paths []string, threshold int) []string {
+	set := map[string]bool{}
+	for _, path := range paths {
+		set[path] = true
+	}
+	for {
+		byDir := map[string][]string{}
+		for path := range set {
+			if dir := parentDir(path); dir != "" {
+				byDir[dir] = append(byDir[dir], path)
+			}
+		}
+		collapsed := false
+		for dir, children := range byDir {
+			if len(children) <= threshold {
+				continue
+			}
+			for _, child := range children {
+				delete(set, child)
+			}
+			set[dir+Wildcard] = true
+			collapsed = true
+		}
+		if !collapsed {
+			break
+		}
+	}
+	return removeCoveredPaths(set)
+}
+
+// parentDir returns the directory containing path including the trailing `/`
+// A wildcard for a directory (e.g. `/assets/*`) is contained in the parent directory (e.g. `/`)
+// The root wildcard `/*` has no parent, so an empty string is returned
+func parentDir(path string) string {
+	trimmed := strings.TrimSuffix(path, "/"+Wildcard)
+	if trimmed == "" {
+		return ""
+	}
+	return trimmed[:strings.LastIndex(trimmed, "/")+1]
+}
+
+// removeCoveredPaths returns the sorted paths that are not already invalidated by a wildcard path
+func removeCoveredPaths(set map[string]bool) []string {
+	wildcards := make([]string, 0)
+	for path := range set {
+		if strings.HasSuffix(path, Wildcard) {
+			wildcards = append(wildcards, strings.TrimSuffix(path, Wildcard))
+		}
+	}
+	result := make([]string, 0, len(set))
+	for path := range set {
+		covered := false
+		for _, prefix := range wildcards {
+			if path != prefix+Wildcard && strings.HasPrefix(path, prefix) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, path)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,58 @@
+package cloudfront
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ReadPathsFile reads the invalidation paths from a file (see ParsePaths)
+// The returned hash is the hex-encoded SHA-256 of the file's contents
+func ReadPathsFile(name string) ([]string, string, error) {
+	raw, err := os.ReadFile(name)
+	if err != nil {
+		return nil, "", err
+	}
+	paths, err := ParsePaths(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", name, err)
+	}
+	sum := sha256.Sum256(raw)
+	return paths, hex.EncodeToString(sum[:]), nil
+}
+
+// ParsePaths parses a list of invalidation paths
+// The list is either a JSON array of strings or newline-delimited
+// When newline-delimited, each line is trimmed and blank lines are ignored
+// The result is sorted without duplicates
+func ParsePaths(raw []byte) ([]string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	paths := make([]string, 0)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &paths); err != nil {
+			return nil, fmt.Errorf("invalid JSON array of paths: %w", err)
+		}
+	} else {
+		for _, line := range strings.Split(string(trimmed), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				paths = append(paths, line)
+			}
+		}
+	}
+
+	set := map[string]bool{}
+	result := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !set[path] {
+			set[path] = true
+			result = append(result, path)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
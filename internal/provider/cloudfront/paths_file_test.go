@@ -0,0 +1,75 @@
+package cloudfront
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParsePaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", raw: "", want: []string{}},
+		{name: "newline-delimited", raw: "/index.html\n/assets/*\n", want: []string{"/assets/*", "/index.html"}},
+		{name: "crlf and blank lines", raw: "\r\n/index.html\r\n\r\n  /about.html  \r\n", want: []string{"/about.html", "/index.html"}},
+		{name: "duplicates", raw: "/index.html\n/index.html\n", want: []string{"/index.html"}},
+		{name: "json array", raw: `["/index.html", "/assets/*"]`, want: []string{"/assets/*", "/index.html"}},
+		{name: "json array with whitespace", raw: "\n  [\"/index.html\"]\n", want: []string{"/index.html"}},
+		{name: "invalid json array", raw: `["/index.html",]`, wantErr: true},
+		{name: "json array of numbers", raw: `[1, 2]`, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParsePaths([]byte(test.raw))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestReadPathsFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "paths.txt")
+	if err := os.WriteFile(name, []byte("/index.html\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	paths, hash, err := ReadPathsFile(name)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(paths, []string{"/index.html"}) {
+		t.Errorf("unexpected paths %v", paths)
+	}
+	if want := "533d04c000982014d1f8236086e50f1495be83e4ddacb39c1e417248c5ba3a94"; hash != want {
+		t.Errorf("expected hash %q, got %q", want, hash)
+	}
+
+	if err := os.WriteFile(name, []byte("/index.html\n/about.html\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, changed, err := ReadPathsFile(name)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if changed == hash {
+		t.Errorf("expected hash to change when the file changes")
+	}
+
+	if _, _, err := ReadPathsFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("expected error for a missing file")
+	}
+}
@@ -0,0 +1,139 @@
+package cloudfront
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidatePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "root wildcard", path: "/*", wantErr: false},
+		{name: "trailing wildcard", path: "/images/*", wantErr: false},
+		{name: "encoded", path: "/my%20file.html", wantErr: false},
+		{name: "unencoded space", path: "/my file.html", wantErr: false},
+		{name: "missing slash", path: "images/*", wantErr: true},
+		{name: "middle wildcard", path: "/images/*.png", wantErr: true},
+		{name: "bad encoding", path: "/100%.html", wantErr: true},
+		{name: "too long", path: "/" + strings.Repeat("a", MaxPathLength), wantErr: true},
+		{name: "too long once encoded", path: "/" + strings.Repeat(" ", MaxPathLength/2), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidatePath(test.path)
+			if got := len(errs) > 0; got != test.wantErr {
+				t.Errorf("ValidatePath(%q) = %v, wantErr %v", test.path, errs, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"/*":                "/*",
+		"/index.html":       "/index.html",
+		"/my file.html":     "/my%20file.html",
+		"/my%20file.html":   "/my%20file.html",
+		"/a{b}|c":           "/a%7Bb%7D%7Cc",
+		"/café/*":           "/caf%C3%A9/*",
+		"/query?a=1&b=2":    "/query?a=1&b=2",
+		"/path/with\"quote": "/path/with%22quote",
+	}
+
+	for input, want := range tests {
+		if got := NormalizePath(input); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestBatchPaths(t *testing.T) {
+	paths := make([]string, 0)
+	for i := 0; i < MaxPathsPerBatch+10; i++ {
+		paths = append(paths, fmt.Sprintf("/file-%d.html", i))
+	}
+	for i := 0; i < MaxWildcardPathsPerBatch+5; i++ {
+		paths = append(paths, fmt.Sprintf("/dir-%d/*", i))
+	}
+
+	batches := BatchPaths(paths)
+	total := 0
+	for i, batch := range batches {
+		wildcards := 0
+		for _, path := range batch {
+			if strings.HasSuffix(path, Wildcard) {
+				wildcards++
+			}
+		}
+		if len(batch) > MaxPathsPerBatch {
+			t.Errorf("batch %d has %d paths, want at most %d", i, len(batch), MaxPathsPerBatch)
+		}
+		if wildcards > MaxWildcardPathsPerBatch {
+			t.Errorf("batch %d has %d wildcard paths, want at most %d", i, wildcards, MaxWildcardPathsPerBatch)
+		}
+		total += len(batch)
+	}
+	if total != len(paths) {
+		t.Errorf("batches contain %d paths, want %d", total, len(paths))
+	}
+	if len(batches) != 3 {
+		t.Errorf("got %d batches, want 3", len(batches))
+	}
+}
+
+func TestEquivalentPaths(t *testing.T) {
+	if !EquivalentPaths([]string{"/my file.html", "/*"}, []string{"/*", "/my%20file.html"}) {
+		t.Error("expected normalized paths to be equivalent")
+	}
+	if EquivalentPaths([]string{"/a", "/b"}, []string{"/a"}) {
+		t.Error("expected different paths to not be equivalent")
+	}
+}
+
+func TestCollapsePaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		paths     []string
+		threshold int
+		want      []string
+	}{
+		{
+			name:      "below threshold",
+			paths:     []string{"/assets/a.js", "/assets/b.js", "/index.html"},
+			threshold: 2,
+			want:      []string{"/assets/a.js", "/assets/b.js", "/index.html"},
+		},
+		{
+			name:      "collapses directory",
+			paths:     []string{"/assets/a.js", "/assets/b.js", "/assets/c.js", "/index.html"},
+			threshold: 2,
+			want:      []string{"/assets/*", "/index.html"},
+		},
+		{
+			name:      "collapses up the tree",
+			paths:     []string{"/a/x/1", "/a/x/2", "/a/y/1", "/a/y/2", "/a/z/1", "/a/z/2", "/b"},
+			threshold: 1,
+			want:      []string{"/*"},
+		},
+		{
+			name:      "removes covered paths",
+			paths:     []string{"/assets/*", "/assets/a.js", "/assets/img/b.png", "/index.html"},
+			threshold: 10,
+			want:      []string{"/assets/*", "/index.html"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := CollapsePaths(test.paths, test.threshold)
+			if fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"math/rand"
+	"time"
+)
+
+const (
+	minThrottleDelay = 5 * time.Second
+	maxThrottleDelay = time.Minute
+)
+
+// throttleWait returns how long to wait before retrying after attempt was throttled
+// It is a variable so that tests can retry without waiting
+var throttleWait = func(attempt int) time.Duration {
+	delay := throttleDelay(attempt)
+	return delay + throttleJitter(delay)
+}
+
+// throttleDelay returns the exponential backoff after attempt, starting at minThrottleDelay and capped at maxThrottleDelay
+func throttleDelay(attempt int) time.Duration {
+	delay := minThrottleDelay
+	for i := 1; i < attempt && delay < maxThrottleDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxThrottleDelay)
+}
+
+// throttleJitter returns a random duration of less than half of delay
+// Concurrent applies against the same distribution then don't retry in lockstep
+func throttleJitter(delay time.Duration) time.Duration {
+	return time.Duration(rand.Int63n(int64(delay / 2)))
+}
+
+// createInvalidationWithRetry creates an invalidation
+// When the distribution already has the maximum number of invalidations in progress,
+// CloudFront returns TooManyInvalidationsInProgress and the request is retried with exponential backoff until deadline
+func createInvalidationWithRetry(ctx context.Context, cfClient *cloudfront.Client, input *cloudfront.CreateInvalidationInput, deadline time.Time) (*cloudfront.CreateInvalidationOutput, error) {
+	for attempt := 1; ; attempt++ {
+		out, err := cfClient.CreateInvalidation(ctx, input)
+		var tmi *cftypes.TooManyInvalidationsInProgress
+		if err == nil || !errors.As(err, &tmi) {
+			return out, err
+		}
+
+		wait := throttleWait(attempt)
+		if time.Now().Add(wait).After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for in-progress invalidations to complete after %d attempts: %w", attempt, err)
+		}
+		tflog.Info(ctx, "Too many Cloudfront Invalidations in progress, waiting to retry", map[string]any{
+			"distribution_id": aws.ToString(input.DistributionId),
+			"attempt":         attempt,
+			"retry_in":        wait.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront/cloudfronttest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 5 * time.Second},
+		{attempt: 2, want: 10 * time.Second},
+		{attempt: 3, want: 20 * time.Second},
+		{attempt: 4, want: 40 * time.Second},
+		{attempt: 5, want: time.Minute},
+		{attempt: 100, want: time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := throttleDelay(test.attempt); got != test.want {
+			t.Errorf("expected a delay of %s after attempt %d, got %s", test.want, test.attempt, got)
+		}
+	}
+}
+
+func TestThrottleJitter(t *testing.T) {
+	for _, delay := range []time.Duration{minThrottleDelay, maxThrottleDelay} {
+		for i := 0; i < 100; i++ {
+			if jitter := throttleJitter(delay); jitter < 0 || jitter >= delay/2 {
+				t.Fatalf("expected jitter for %s to be less than %s, got %s", delay, delay/2, jitter)
+			}
+		}
+	}
+}
+
+func TestCreateInvalidationWithRetry(t *testing.T) {
+	ctx := context.Background()
+	server := cloudfronttest.NewServer(t)
+	cfClient := server.Client().Cloudfront()
+	input := func(distributionId string) *cloudfront.CreateInvalidationInput {
+		return &cloudfront.CreateInvalidationInput{
+			DistributionId: aws.String(distributionId),
+			InvalidationBatch: &cftypes.InvalidationBatch{
+				CallerReference: aws.String("reference"),
+				Paths:           &cftypes.Paths{Quantity: aws.Int32(1), Items: []string{"/*"}},
+			},
+		}
+	}
+
+	t.Run("retries while throttled", func(t *testing.T) {
+		server.SetError("E1111111111111", "TooManyInvalidationsInProgress")
+		defer setThrottleWait(func(attempt int) time.Duration {
+			// CloudFront accepts the invalidation once the others complete
+			if attempt == 2 {
+				server.SetError("E1111111111111", "")
+			}
+			return time.Millisecond
+		})()
+
+		out, err := createInvalidationWithRetry(ctx, cfClient, input("E1111111111111"), time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if id := aws.ToString(out.Invalidation.Id); id != "IE1111111111111" {
+			t.Errorf("expected invalidation IE1111111111111, got %s", id)
+		}
+		if requests := server.Requests("E1111111111111"); requests != 3 {
+			t.Errorf("expected 3 requests, got %d", requests)
+		}
+	})
+
+	t.Run("gives up at the deadline", func(t *testing.T) {
+		server.SetError("E2222222222222", "TooManyInvalidationsInProgress")
+		defer setThrottleWait(func(attempt int) time.Duration { return 100 * time.Millisecond })()
+
+		_, err := createInvalidationWithRetry(ctx, cfClient, input("E2222222222222"), time.Now().Add(150*time.Millisecond))
+		var tmi *cftypes.TooManyInvalidationsInProgress
+		if !errors.As(err, &tmi) || !strings.Contains(err.Error(), "after 2 attempts") {
+			t.Errorf("expected to give up after 2 attempts, got %v", err)
+		}
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		server.SetError("E3333333333333", "AccessDenied")
+		defer setThrottleWait(func(attempt int) time.Duration {
+			t.Errorf("unexpected retry after attempt %d", attempt)
+			return time.Millisecond
+		})()
+
+		if _, err := createInvalidationWithRetry(ctx, cfClient, input("E3333333333333"), time.Now().Add(time.Minute)); err == nil {
+			t.Error("expected an error")
+		}
+		if requests := server.Requests("E3333333333333"); requests != 1 {
+			t.Errorf("expected a single request, got %d", requests)
+		}
+	})
+}
+
+// setThrottleWait replaces throttleWait and returns a function that restores it
+func setThrottleWait(wait func(attempt int) time.Duration) func() {
+	prev := throttleWait
+	throttleWait = wait
+	return func() {
+		throttleWait = prev
+	}
+}
@@ -3,11 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
@@ -16,11 +25,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
 	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
-	"regexp"
+	"strings"
 	"time"
 )
 
-var _ resource.Resource = &CloudfrontDistributionInvalidationResource{}
+var (
+	_ resource.Resource                     = &CloudfrontDistributionInvalidationResource{}
+	_ resource.ResourceWithImportState      = &CloudfrontDistributionInvalidationResource{}
+	_ resource.ResourceWithConfigValidators = &CloudfrontDistributionInvalidationResource{}
+	_ resource.ResourceWithModifyPlan       = &CloudfrontDistributionInvalidationResource{}
+	_ resource.ResourceWithValidateConfig   = &CloudfrontDistributionInvalidationResource{}
+	_ resource.ResourceWithUpgradeState     = &CloudfrontDistributionInvalidationResource{}
+)
 
 type CloudfrontDistributionInvalidationResource struct {
 	client *conns.Client
@@ -31,12 +47,24 @@ func NewCloudfrontDistributionInvalidationResource() resource.Resource {
 }
 
 type CloudfrontDistributionInvalidationModel struct {
-	Id             types.String   `tfsdk:"id"`
-	DistributionId types.String   `tfsdk:"distribution_id"`
-	Paths          types.Set      `tfsdk:"paths"`
-	Status         types.String   `tfsdk:"status"`
-	Triggers       types.Map      `tfsdk:"triggers"`
-	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+	Id                   types.String      `tfsdk:"id"`
+	AlwaysInvalidate     types.Bool        `tfsdk:"always_invalidate"`
+	Arn                  types.String      `tfsdk:"arn"`
+	CallerReference      types.String      `tfsdk:"caller_reference"`
+	CollapseThreshold    types.Int64       `tfsdk:"collapse_threshold"`
+	CreateTime           timetypes.RFC3339 `tfsdk:"create_time"`
+	DistributionId       types.String      `tfsdk:"distribution_id"`
+	DistributionSelector types.Object      `tfsdk:"distribution_selector"`
+	EffectivePaths       types.Set         `tfsdk:"effective_paths"`
+	InvalidateOnDestroy  types.Bool        `tfsdk:"invalidate_on_destroy"`
+	InvalidationIds      types.List        `tfsdk:"invalidation_ids"`
+	Paths                types.Set         `tfsdk:"paths"`
+	PathsFile            types.String      `tfsdk:"paths_file"`
+	PathsFileHash        types.String      `tfsdk:"paths_file_hash"`
+	Status               types.String      `tfsdk:"status"`
+	WaitForCompletion    types.Bool        `tfsdk:"wait_for_completion"`
+	Triggers             types.Map         `tfsdk:"triggers"`
+	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
 }
 
 func (r *CloudfrontDistributionInvalidationResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -46,28 +74,105 @@ func (r *CloudfrontDistributionInvalidationResource) Metadata(ctx context.Contex
 func (r *CloudfrontDistributionInvalidationResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		MarkdownDescription: "",
+		// Version 1 records every batch in `invalidation_ids` (see UpgradeState)
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
-			"distribution_id": schema.StringAttribute{
-				MarkdownDescription: "The Cloudfront Distribution ID where an invalidation should be created.",
-				Required:            true,
+			"always_invalidate": alwaysInvalidateAttribute(),
+			"arn": schema.StringAttribute{
+				MarkdownDescription: "An ARN-like identifier of the first invalidation in the form `arn:<partition>:cloudfront::<account-id>:distribution/<distribution-id>/invalidation/<id>`. " +
+					"CloudFront does not assign ARNs to invalidations; this is synthesized to correlate with CloudTrail events.",
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"caller_reference": schema.StringAttribute{
+				MarkdownDescription: "The caller reference of the first invalidation. " +
+					"If omitted, this is a hash of `distribution_id`, `effective_paths`, `triggers`, and a value generated when the resource is created, " +
+					"so that retried requests do not create a duplicate invalidation but a replaced resource creates a new one. " +
+					"Additional invalidations created for batches of `paths` append `-<n>` to the caller reference.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
-			"paths": schema.SetAttribute{
+			"collapse_threshold": schema.Int64Attribute{
+				MarkdownDescription: "When set, paths are collapsed to minimize the number of billable paths: " +
+					"when more than `collapse_threshold` paths share a directory, they are replaced with a wildcard for the directory (e.g. `/assets/*`). " +
+					"The paths that are submitted to CloudFront are exposed in `effective_paths`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"create_time": schema.StringAttribute{
+				CustomType:          timetypes.RFC3339Type{},
+				MarkdownDescription: "The date and time (RFC3339) the first invalidation was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"distribution_id": schema.StringAttribute{
+				MarkdownDescription: "The Cloudfront Distribution ID or ARN where an invalidation should be created. " +
+					"Exactly one of `distribution_id` or `distribution_selector` must be configured; when using `distribution_selector`, this is the resolved distribution ID.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(distributionIdRequiresReplace,
+						"Changing the distribution forces a new invalidation.",
+						"Changing the distribution forces a new invalidation."),
+				},
+				Validators: []validator.String{
+					DistributionIdValidator{},
+				},
+			},
+			"distribution_selector": distributionSelectorSchema("Selects the Cloudfront Distribution where an invalidation should be created instead of `distribution_id`. " +
+				"The selector must match exactly one distribution."),
+			"effective_paths": schema.SetAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "A list of paths to invalidate. Each path *must* start with `/`.",
-				Required:            true,
+				MarkdownDescription: "The paths that are submitted to CloudFront after URL-encoding and collapsing `paths`. This is known during plan when `paths` is known.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"invalidate_on_destroy": invalidateOnDestroyAttribute(),
+			"invalidation_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "The IDs of every invalidation that was created. " +
+					"When `paths` exceed CloudFront's in-progress quotas, they are split into multiple invalidations that are created one after another.",
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"paths": schema.SetAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "A list of paths to invalidate. Each path *must* start with `/` and may only contain `*` as the last character. " +
+					"Characters that CloudFront requires to be URL-encoded (e.g. spaces) are encoded before the invalidation is created. " +
+					"Exactly one of `paths` or `paths_file` must be configured.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					usePathsStateWhenNotConfigured{},
 					setplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.Set{
-					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "")),
+					setvalidator.ValueStringsAre(InvalidationPathValidator{}),
 				},
 			},
+			"paths_file":      pathsFileAttribute(),
+			"paths_file_hash": pathsFileHashAttribute(),
 			"status": schema.StringAttribute{
-				Description: "The status of the invalidation.",
+				Description: "The status of the invalidation. This is `Completed` only once every invalidation has completed, or `Skipped` when the provider skips invalidations.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -77,13 +182,20 @@ func (r *CloudfrontDistributionInvalidationResource) Schema(ctx context.Context,
 				ElementType:         types.StringType,
 				MarkdownDescription: "A map of triggers that, when changed, will force Terraform to create a new invalidation.",
 				Optional:            true,
-				Computed:            true,
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.RequiresReplace(),
 				},
 			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, Terraform waits for every invalidation to complete before finishing the apply. " +
+					"When `false`, Terraform finishes as soon as CloudFront accepts the invalidations and `status` is refreshed on subsequent reads. " +
+					"Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
 			"id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the invalidation.",
+				MarkdownDescription: "The ID of the first invalidation.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -93,6 +205,9 @@ func (r *CloudfrontDistributionInvalidationResource) Schema(ctx context.Context,
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
 			}),
 		},
 	}
@@ -108,7 +223,7 @@ func (r *CloudfrontDistributionInvalidationResource) Configure(ctx context.Conte
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -124,24 +239,35 @@ func (r *CloudfrontDistributionInvalidationResource) Create(ctx context.Context,
 		return
 	}
 
-	createTimeout, diags := data.Timeouts.Create(ctx, 30*time.Minute)
+	createTimeout, diags := data.Timeouts.Create(ctx, r.client.DefaultTimeouts.CreateTimeout())
+	response.Diagnostics.Append(diags...)
+	opts, diags := r.createOptions(ctx, data, createTimeout)
+	response.Diagnostics.Append(diags...)
+	nonce, diags := newCreateNonce(ctx, response.Private)
 	response.Diagnostics.Append(diags...)
-	paths := make([]string, 0)
-	response.Diagnostics.Append(data.Paths.ElementsAs(ctx, &paths, false)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
+	opts.Triggers = cloudfront.WithTrigger(opts.Triggers, cloudfront.TriggerCreateNonce, nonce)
 
-	inval, diags := cloudfront.CreateInvalidation(ctx, r.client, data.DistributionId.ValueString(), paths, createTimeout)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	if !data.DistributionSelector.IsNull() {
+		distributionIds, diags := resolveDistributionSelector(ctx, r.client, data.DistributionSelector)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		if len(distributionIds) > 1 {
+			response.Diagnostics.AddAttributeError(path.Root("distribution_selector"), "Multiple AWS Cloudfront Distributions found",
+				fmt.Sprintf("`distribution_selector` must match exactly one distribution, found: %s. "+
+					"Use awsex_cloudfront_distribution_invalidations to invalidate multiple distributions.", strings.Join(distributionIds, ", ")))
+			return
+		}
+		data.DistributionId = types.StringValue(distributionIds[0])
 	}
 
-	data.Id = types.StringPointerValue(inval.Id)
-	data.Status = types.StringPointerValue(inval.Status)
-	if data.Triggers.IsUnknown() {
-		data.Triggers = types.MapNull(types.StringType)
+	response.Diagnostics.Append(r.invalidate(ctx, &data, opts)...)
+	if response.Diagnostics.HasError() {
+		return
 	}
 
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
@@ -153,25 +279,311 @@ func (r *CloudfrontDistributionInvalidationResource) Read(ctx context.Context, r
 	if response.Diagnostics.HasError() {
 		return
 	}
+	readTimeout, diags := data.Timeouts.Read(ctx, r.client.DefaultTimeouts.ReadTimeout())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	inval, diags := cloudfront.FindInvalidation(ctx, r.client, data.DistributionId.ValueString(), data.Id.ValueString())
+	ids := []string{data.Id.ValueString()}
+	if !data.InvalidationIds.IsNull() && !data.InvalidationIds.IsUnknown() {
+		response.Diagnostics.Append(data.InvalidationIds.ElementsAs(ctx, &ids, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	invals, diags := cloudfront.FindInvalidationBatches(ctx, r.client, distributionId(data.DistributionId), ids)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	if inval == nil {
+	if len(invals) == 0 {
 		response.State.RemoveResource(ctx)
-	} else {
-		data.Id = types.StringPointerValue(inval.Id)
-		data.Status = types.StringPointerValue(inval.Status)
+		return
 	}
 
+	response.Diagnostics.Append(r.setResult(ctx, &data, invals)...)
+
+	// Paths are missing after an import
+	// Otherwise, only refresh paths when they differ from the invalidation so that un-normalized paths don't cause drift
+	// Collapsed paths always differ from the invalidation, so they are not refreshed
+	// Paths loaded from `paths_file` are tracked by `paths_file_hash`, so they are not refreshed either
+	// Skipped invalidations were never created, so there are no paths to refresh
+	statePaths := make([]string, 0)
+	if !data.Paths.IsNull() {
+		response.Diagnostics.Append(data.Paths.ElementsAs(ctx, &statePaths, false)...)
+	}
+	actualPaths := cloudfront.InvalidationPaths(invals)
+	skipped := cloudfront.AggregateStatus(invals) == cloudfront.StatusSkipped
+	if !skipped && (data.Paths.IsNull() || !cloudfront.EquivalentPaths(statePaths, actualPaths) && data.CollapseThreshold.IsNull() && data.PathsFile.IsNull()) {
+		data.Paths, diags = types.SetValueFrom(ctx, types.StringType, actualPaths)
+		response.Diagnostics.Append(diags...)
+	}
+	if data.EffectivePaths.IsNull() {
+		data.EffectivePaths, diags = types.SetValueFrom(ctx, types.StringType, actualPaths)
+		response.Diagnostics.Append(diags...)
+	}
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
 func (r *CloudfrontDistributionInvalidationResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data, state CloudfrontDistributionInvalidationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Other changes replace the resource, so only `always_invalidate` creates an invalidation here
+	if data.AlwaysInvalidate.ValueBool() {
+		updateTimeout, diags := data.Timeouts.Update(ctx, r.client.DefaultTimeouts.UpdateTimeout())
+		response.Diagnostics.Append(diags...)
+		opts, diags := r.createOptions(ctx, data, updateTimeout)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		// Chain from the previous invalidation so that each apply gets a new caller reference
+		opts.CallerReference = ""
+		opts.Triggers = cloudfront.WithTrigger(opts.Triggers, cloudfront.TriggerAlwaysInvalidate, state.Id.ValueString())
+		response.Diagnostics.Append(r.invalidate(ctx, &data, opts)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan plans a new invalidation on every apply when `always_invalidate` is enabled
+// When the plan will submit an invalidation, a warning summarizes its impact (see cloudfront.EstimateImpact)
+func (r *CloudfrontDistributionInvalidationResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		var state CloudfrontDistributionInvalidationModel
+		response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+		if state.InvalidateOnDestroy.ValueBool() {
+			r.warnImpact(ctx, "When destroyed", state, &response.Diagnostics)
+		}
+		return
+	}
+
+	var plan CloudfrontDistributionInvalidationModel
+	var configPaths types.Set
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("paths"), &configPaths)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	var diags diag.Diagnostics
+	plan.Paths, plan.PathsFileHash, diags = planPathsFile(ctx, plan.PathsFile, configPaths)
+	response.Diagnostics.Append(diags...)
+	if plan.EffectivePaths.IsUnknown() {
+		plan.EffectivePaths, diags = planEffectivePaths(ctx, plan.Paths, plan.CollapseThreshold)
+		response.Diagnostics.Append(diags...)
+	}
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if request.State.Raw.IsNull() {
+		r.warnImpact(ctx, "When created", plan, &response.Diagnostics)
+		return
+	}
+
+	var state CloudfrontDistributionInvalidationModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if pathsFileChanged(state.PathsFileHash, plan.PathsFileHash) {
+		response.RequiresReplace = append(response.RequiresReplace, path.Root("paths_file_hash"))
+	}
+	if r.requiresReplace(state, plan) {
+		if state.InvalidateOnDestroy.ValueBool() {
+			r.warnImpact(ctx, "When destroyed", state, &response.Diagnostics)
+		}
+		r.warnImpact(ctx, "When replaced", plan, &response.Diagnostics)
+		return
+	}
+	if !plan.AlwaysInvalidate.ValueBool() {
+		return
+	}
+
+	r.warnImpact(ctx, "Because `always_invalidate` is enabled", plan, &response.Diagnostics)
+	plan.Id = types.StringUnknown()
+	plan.Arn = types.StringUnknown()
+	plan.CallerReference = types.StringUnknown()
+	plan.CreateTime = timetypes.NewRFC3339Unknown()
+	plan.EffectivePaths, diags = planEffectivePaths(ctx, plan.Paths, plan.CollapseThreshold)
+	response.Diagnostics.Append(diags...)
+	plan.InvalidationIds = types.ListUnknown(types.StringType)
+	plan.Status = types.StringUnknown()
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+// requiresReplace reports whether the plan replaces the invalidation, which ModifyPlan needs to choose its warning
+func (r *CloudfrontDistributionInvalidationResource) requiresReplace(state, plan CloudfrontDistributionInvalidationModel) bool {
+	return pathsFileChanged(state.PathsFileHash, plan.PathsFileHash) ||
+		!plan.CallerReference.Equal(state.CallerReference) ||
+		plan.DistributionId.IsUnknown() || distributionId(plan.DistributionId) != distributionId(state.DistributionId) ||
+		!plan.DistributionSelector.Equal(state.DistributionSelector) ||
+		!plan.Paths.Equal(state.Paths) ||
+		!plan.Triggers.Equal(state.Triggers)
+}
+
+// warnImpact adds a warning that summarizes the invalidation that will be submitted for model
+func (r *CloudfrontDistributionInvalidationResource) warnImpact(ctx context.Context, action string, model CloudfrontDistributionInvalidationModel, diags *diag.Diagnostics) {
+	paths := make([]string, 0)
+	if model.Paths.IsUnknown() || model.Paths.ElementsAs(ctx, &paths, false).HasError() {
+		return
+	}
+	impact := cloudfront.EstimateImpact(map[string][]string{distributionId(model.DistributionId): paths}, int(model.CollapseThreshold.ValueInt64()))
+	diags.Append(impactWarning(r.client, action, impact, "")...)
+}
+
+// ValidateConfig ensures that a fixed caller reference is not used for an invalidation that is repeated on every apply
+func (r *CloudfrontDistributionInvalidationResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data CloudfrontDistributionInvalidationModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if data.AlwaysInvalidate.ValueBool() && !data.CallerReference.IsNull() {
+		response.Diagnostics.AddAttributeError(path.Root("caller_reference"), "Invalid Attribute Combination",
+			"`caller_reference` cannot be configured when `always_invalidate` is `true` because CloudFront would return the existing invalidation instead of creating a new one.")
+	}
+}
+
+func (r *CloudfrontDistributionInvalidationResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("distribution_id"),
+			path.MatchRoot("distribution_selector"),
+		),
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("paths"),
+			path.MatchRoot("paths_file"),
+		),
+	}
 }
 
 func (r *CloudfrontDistributionInvalidationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data CloudfrontDistributionInvalidationModel
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() || !data.InvalidateOnDestroy.ValueBool() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.client.DefaultTimeouts.DeleteTimeout())
+	response.Diagnostics.Append(diags...)
+	opts, diags := r.createOptions(ctx, data, deleteTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	opts.CallerReference = ""
+	opts.Triggers = cloudfront.WithTrigger(opts.Triggers, cloudfront.TriggerInvalidateOnDestroy, data.Id.ValueString())
+	response.Diagnostics.Append(r.invalidate(ctx, &data, opts)...)
+}
+
+func (r *CloudfrontDistributionInvalidationResource) createOptions(ctx context.Context, data CloudfrontDistributionInvalidationModel,
+	timeout time.Duration) (cloudfront.CreateOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := cloudfront.CreateOptions{
+		CreateTimeout:     timeout,
+		WaitForCompletion: data.WaitForCompletion.ValueBool(),
+		CallerReference:   data.CallerReference.ValueString(),
+		CollapseThreshold: int(data.CollapseThreshold.ValueInt64()),
+	}
+	if !data.Triggers.IsNull() && !data.Triggers.IsUnknown() {
+		diags.Append(data.Triggers.ElementsAs(ctx, &opts.Triggers, false)...)
+	}
+	return opts, diags
+}
+
+// invalidate creates an invalidation for the configured paths and records the result in model
+func (r *CloudfrontDistributionInvalidationResource) invalidate(ctx context.Context, model *CloudfrontDistributionInvalidationModel, opts cloudfront.CreateOptions) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	paths := make([]string, 0)
+	diags.Append(model.Paths.ElementsAs(ctx, &paths, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	invals, d := cloudfront.CreateInvalidation(ctx, r.client, distributionId(model.DistributionId), paths, opts)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(r.setResult(ctx, model, invals)...)
+	model.EffectivePaths, d = types.SetValueFrom(ctx, types.StringType, cloudfront.EffectivePaths(paths, opts.CollapseThreshold))
+	diags.Append(d...)
+	return diags
+}
+
+// ImportState imports an existing invalidation using an identifier in the form `distribution_id/invalidation_id`
+// The distribution may be an ARN, and the identifier may be the invalidation's ARN (see cloudfront.InvalidationArn)
+func (r *CloudfrontDistributionInvalidationResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	distributionId, id := "", ""
+	if i := strings.LastIndex(request.ID, "/"); i >= 0 {
+		distributionId, id = strings.TrimSuffix(request.ID[:i], "/invalidation"), request.ID[i+1:]
+	}
+	if distributionId == "" || id == "" {
+		response.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: distribution_id/invalidation_id or an invalidation ARN. Got: %q", request.ID),
+		)
+		return
+	}
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("distribution_id"), distributionId)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), id)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("invalidation_ids"), []string{id})...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("wait_for_completion"), true)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("always_invalidate"), false)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("invalidate_on_destroy"), false)...)
+}
+
+func (r *CloudfrontDistributionInvalidationResource) setResult(ctx context.Context, model *CloudfrontDistributionInvalidationModel, invals []*cftypes.Invalidation) diag.Diagnostics {
+	ids := cloudfront.InvalidationIds(invals)
+	prior := *model
+	model.Arn = types.StringNull()
+	model.CallerReference = types.StringNull()
+	model.CreateTime = timetypes.NewRFC3339Null()
+	var diags, d diag.Diagnostics
+	if len(invals) > 0 {
+		// The ARN of an invalidation never changes, so it is only looked up for a new invalidation
+		sameId := prior.Id.ValueString() == aws.ToString(invals[0].Id)
+		if sameId && !prior.Arn.IsNull() && !prior.Arn.IsUnknown() {
+			model.Arn = prior.Arn
+		} else {
+			var invalArn *string
+			invalArn, d = cloudfront.InvalidationArn(ctx, r.client, distributionId(model.DistributionId), aws.ToString(invals[0].Id))
+			diags.Append(d...)
+			model.Arn = types.StringPointerValue(invalArn)
+		}
+		model.Id = types.StringPointerValue(invals[0].Id)
+		model.CreateTime = timetypes.NewRFC3339TimePointerValue(invals[0].CreateTime)
+		if invals[0].InvalidationBatch != nil {
+			model.CallerReference = types.StringPointerValue(invals[0].InvalidationBatch.CallerReference)
+		}
+		// Skipped invalidations are found without these, so keep the values recorded when they were skipped
+		if sameId && model.CreateTime.IsNull() && !prior.CreateTime.IsUnknown() {
+			model.CreateTime = prior.CreateTime
+		}
+		if sameId && model.CallerReference.IsNull() && !prior.CallerReference.IsUnknown() {
+			model.CallerReference = prior.CallerReference
+		}
+	}
+	model.Status = types.StringValue(cloudfront.AggregateStatus(invals))
+	model.InvalidationIds, d = types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	return diags
 }
@@ -8,7 +8,18 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
 	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	cfinvalidation "github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront/cloudfronttest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -43,10 +54,82 @@ resource "awsex_cloudfront_distribution_invalidation" "test" {
 					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidation.test", "status", "Completed"),
 				),
 			},
+			{
+				ResourceName:            "awsex_cloudfront_distribution_invalidation.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccDistributionInvalidationImportStateIdFunc("awsex_cloudfront_distribution_invalidation.test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "triggers"},
+			},
 		},
 	})
 }
 
+func TestAccDistributionInvalidationPathsFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	cdnId := testAccCreateCdn(t, "test", "www.example.com")
+	pathsFile := filepath.Join(t.TempDir(), "paths.json")
+	writePathsFile := func(content string) func() {
+		return func() {
+			if err := os.WriteFile(pathsFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	config := providerConfig + fmt.Sprintf(`
+resource "awsex_cloudfront_distribution_invalidation" "test" {
+  distribution_id = %[1]q
+  paths_file      = %[2]q
+}
+`, cdnId, pathsFile)
+
+	var firstId string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writePathsFile(`["/index.html", "/about.html"]`),
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidation.test", "paths.#", "2"),
+					resource.TestCheckResourceAttrSet("awsex_cloudfront_distribution_invalidation.test", "paths_file_hash"),
+					resource.TestCheckResourceAttrWith("awsex_cloudfront_distribution_invalidation.test", "id", func(value string) error {
+						firstId = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: writePathsFile("/index.html\n/about.html\n/contact.html\n"),
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidation.test", "paths.#", "3"),
+					resource.TestCheckResourceAttrWith("awsex_cloudfront_distribution_invalidation.test", "id", func(value string) error {
+						if value == firstId {
+							return fmt.Errorf("expected a new invalidation when paths_file changed")
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDistributionInvalidationImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["distribution_id"], rs.Primary.ID), nil
+	}
+}
+
 func testAccCreateCdn(t *testing.T, name, domainName string) string {
 	ctx := context.Background()
 	cfg, err := config.LoadDefaultConfig(ctx)
@@ -121,3 +204,226 @@ func testAccCreateCdn(t *testing.T, name, domainName string) string {
 
 	return *out.Distribution.Id
 }
+
+func testCloudfrontDistributionInvalidationState() map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"always_invalidate":     testBool(false),
+		"arn":                   testString("arn:aws:cloudfront::123456789012:distribution/E1111111111111/invalidation/I1"),
+		"caller_reference":      testString("reference"),
+		"create_time":           testString("2024-01-01T00:00:00Z"),
+		"distribution_id":       testString("E1111111111111"),
+		"effective_paths":       testStringSet("/*"),
+		"id":                    testString("I1"),
+		"invalidate_on_destroy": testBool(false),
+		"invalidation_ids":      testStringList("I1"),
+		"paths":                 testStringSet("/*"),
+		"status":                testString("Completed"),
+		"wait_for_completion":   testBool(true),
+	}
+}
+
+func TestCloudfrontDistributionInvalidationPlanReplace(t *testing.T) {
+	tests := map[string]struct {
+		config      map[string]tftypes.Value
+		wantReplace bool
+	}{
+		"unchanged": {},
+		"invalidate_on_destroy": {
+			config: map[string]tftypes.Value{"invalidate_on_destroy": testBool(true)},
+		},
+		"always_invalidate": {
+			config: map[string]tftypes.Value{"always_invalidate": testBool(true)},
+		},
+		"wait_for_completion": {
+			config: map[string]tftypes.Value{"wait_for_completion": testBool(false)},
+		},
+		"distribution_id by arn": {
+			config: map[string]tftypes.Value{"distribution_id": testString("arn:aws:cloudfront::123456789012:distribution/E1111111111111")},
+		},
+		"distribution_id": {
+			config:      map[string]tftypes.Value{"distribution_id": testString("E2222222222222")},
+			wantReplace: true,
+		},
+		"triggers": {
+			config:      map[string]tftypes.Value{"triggers": testStringMap(map[string]string{"version": "2"})},
+			wantReplace: true,
+		},
+		"paths": {
+			config:      map[string]tftypes.Value{"paths": testStringSet("/index.html")},
+			wantReplace: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"distribution_id": testString("E1111111111111"),
+				"paths":           testStringSet("/*"),
+			}
+			for key, value := range test.config {
+				config[key] = value
+			}
+			plan := testPlanResourceChange(t, "awsex_cloudfront_distribution_invalidation", testCloudfrontDistributionInvalidationState(), config)
+			if replace := len(plan.RequiresReplace) > 0; replace != test.wantReplace {
+				t.Errorf("expected replace %t, got %v", test.wantReplace, plan.RequiresReplace)
+			}
+			if detail, _ := plan.Warning("AWS Cloudfront Invalidations planned"); strings.HasPrefix(detail, "When replaced") != test.wantReplace {
+				t.Errorf("expected a warning for the replacement %t, got %q", test.wantReplace, detail)
+			}
+		})
+	}
+}
+
+func TestCloudfrontDistributionInvalidationImportState(t *testing.T) {
+	tests := map[string]struct {
+		id                 string
+		wantDistributionId string
+		wantId             string
+		wantErr            bool
+	}{
+		"id": {
+			id:                 "E1111111111111/I1",
+			wantDistributionId: "E1111111111111",
+			wantId:             "I1",
+		},
+		"distribution arn": {
+			id:                 "arn:aws:cloudfront::123456789012:distribution/E1111111111111/I1",
+			wantDistributionId: "arn:aws:cloudfront::123456789012:distribution/E1111111111111",
+			wantId:             "I1",
+		},
+		"invalidation arn": {
+			id:                 "arn:aws:cloudfront::123456789012:distribution/E1111111111111/invalidation/I1",
+			wantDistributionId: "arn:aws:cloudfront::123456789012:distribution/E1111111111111",
+			wantId:             "I1",
+		},
+		"missing invalidation": {
+			id:      "E1111111111111/",
+			wantErr: true,
+		},
+		"missing distribution": {
+			id:      "I1",
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	r := &CloudfrontDistributionInvalidationResource{}
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response := &fwresource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, fwresource.ImportStateRequest{ID: test.id}, response)
+			if response.Diagnostics.HasError() != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, response.Diagnostics)
+			}
+			if test.wantErr {
+				return
+			}
+			var got CloudfrontDistributionInvalidationModel
+			response.State.Get(ctx, &got)
+			if got.DistributionId.ValueString() != test.wantDistributionId || got.Id.ValueString() != test.wantId {
+				t.Errorf("expected %s/%s, got %s/%s", test.wantDistributionId, test.wantId, got.DistributionId.ValueString(), got.Id.ValueString())
+			}
+		})
+	}
+}
+
+func TestCloudfrontDistributionInvalidationSetResultSkipped(t *testing.T) {
+	ctx := context.Background()
+	// Without a client, any lookup of the account for the ARN would fail
+	r := &CloudfrontDistributionInvalidationResource{}
+	model := CloudfrontDistributionInvalidationModel{
+		DistributionId:  types.StringValue("E1111111111111"),
+		Id:              types.StringUnknown(),
+		Arn:             types.StringUnknown(),
+		CallerReference: types.StringUnknown(),
+		CreateTime:      timetypes.NewRFC3339Unknown(),
+	}
+	skipped := cfinvalidation.SkippedInvalidation("reference", []string{"/*"})
+	if diags := r.setResult(ctx, &model, []*cftypes.Invalidation{skipped}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	created := model
+
+	found, diags := cfinvalidation.FindInvalidation(ctx, nil, "E1111111111111", aws.ToString(skipped.Id))
+	if diags.HasError() {
+		t.Fatalf("unexpected error finding the skipped invalidation: %v", diags)
+	}
+	if diags := r.setResult(ctx, &model, []*cftypes.Invalidation{found}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !model.Arn.IsNull() {
+		t.Errorf("expected a skipped invalidation to have no ARN, got %s", model.Arn)
+	}
+	if model.CallerReference.ValueString() != "reference" || !model.CreateTime.Equal(created.CreateTime) {
+		t.Errorf("expected the refresh to keep caller_reference and create_time, got %s and %s", model.CallerReference, model.CreateTime)
+	}
+	if model.Status.ValueString() != cfinvalidation.StatusSkipped {
+		t.Errorf("expected status %s, got %s", cfinvalidation.StatusSkipped, model.Status)
+	}
+}
+
+func TestCloudfrontDistributionInvalidationPlanEffectivePaths(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]tftypes.Value
+		want   []string
+	}{
+		"encoded": {
+			config: map[string]tftypes.Value{"paths": testStringSet("/a b", "/c/1")},
+			want:   []string{"/a%20b", "/c/1"},
+		},
+		"collapsed": {
+			config: map[string]tftypes.Value{"paths": testStringSet("/a b", "/c/1", "/c/2", "/c/3"), "collapse_threshold": tftypes.NewValue(tftypes.Number, 2)},
+			want:   []string{"/a%20b", "/c/*"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{"distribution_id": testString("E1111111111111")}
+			for key, value := range test.config {
+				config[key] = value
+			}
+			plan := testPlanResourceChange(t, "awsex_cloudfront_distribution_invalidation", nil, config)
+			var elements []tftypes.Value
+			if err := plan.Attribute(t, "effective_paths").As(&elements); err != nil {
+				t.Fatalf("expected known effective_paths, got %s", err)
+			}
+			got := make([]string, 0, len(elements))
+			for _, element := range elements {
+				var cur string
+				element.As(&cur)
+				got = append(got, cur)
+			}
+			if !cfinvalidation.EquivalentPaths(got, test.want) || len(got) != len(test.want) {
+				t.Errorf("expected effective_paths %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCloudfrontDistributionInvalidationReplace(t *testing.T) {
+	server := cloudfronttest.NewServer(t)
+	provider := newTestProviderServer(t, server.Client())
+	config := map[string]tftypes.Value{
+		"distribution_id":     testString("E1111111111111"),
+		"paths":               testStringSet("/*"),
+		"wait_for_completion": testBool(false),
+	}
+
+	created := provider.Apply(t, "awsex_cloudfront_distribution_invalidation", nil, nil, config)
+	// A replacement is planned and applied from a null prior state, like a new resource
+	replaced := provider.Apply(t, "awsex_cloudfront_distribution_invalidation", nil, nil, config)
+	created.CheckErrors(t)
+	replaced.CheckErrors(t)
+
+	if createdId, replacedId := created.Attributes(t)["id"], replaced.Attributes(t)["id"]; createdId.Equal(replacedId) {
+		t.Errorf("expected the replacement to create a new invalidation, got %s for both", createdId)
+	}
+	if got := server.Invalidations("E1111111111111"); got != 2 {
+		t.Errorf("expected 2 invalidations, got %d", got)
+	}
+}
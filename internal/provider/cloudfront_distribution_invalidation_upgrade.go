@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CloudfrontDistributionInvalidationModelV0 is the state of awsex_cloudfront_distribution_invalidation before schema versioning (<= 0.1.3)
+type CloudfrontDistributionInvalidationModelV0 struct {
+	Id             types.String   `tfsdk:"id"`
+	DistributionId types.String   `tfsdk:"distribution_id"`
+	Paths          types.Set      `tfsdk:"paths"`
+	Status         types.String   `tfsdk:"status"`
+	Triggers       types.Map      `tfsdk:"triggers"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *CloudfrontDistributionInvalidationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   cloudfrontDistributionInvalidationSchemaV0(ctx),
+			StateUpgrader: upgradeCloudfrontDistributionInvalidationStateV0,
+		},
+	}
+}
+
+func cloudfrontDistributionInvalidationSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"distribution_id": schema.StringAttribute{Required: true},
+			"paths":           schema.SetAttribute{ElementType: types.StringType, Required: true},
+			"status":          schema.StringAttribute{Computed: true},
+			"triggers":        schema.MapAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+			"id":              schema.StringAttribute{Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// upgradeCloudfrontDistributionInvalidationStateV0 adds the attributes introduced in version 1
+// The invalidation was created in a single batch, so `id` is the only invalidation ID
+// Computed attributes that are not in the prior state are null until the next refresh
+func upgradeCloudfrontDistributionInvalidationStateV0(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	var prior CloudfrontDistributionInvalidationModelV0
+	response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	current := CloudfrontDistributionInvalidationModel{
+		Id:                   prior.Id,
+		AlwaysInvalidate:     types.BoolValue(false),
+		Arn:                  types.StringNull(),
+		CallerReference:      types.StringNull(),
+		CollapseThreshold:    types.Int64Null(),
+		CreateTime:           timetypes.NewRFC3339Null(),
+		DistributionId:       prior.DistributionId,
+		DistributionSelector: types.ObjectNull(DistributionSelectorModel{}.AttrTypes()),
+		EffectivePaths:       types.SetNull(types.StringType),
+		InvalidateOnDestroy:  types.BoolValue(false),
+		InvalidationIds:      types.ListNull(types.StringType),
+		Paths:                prior.Paths,
+		PathsFile:            types.StringNull(),
+		PathsFileHash:        types.StringNull(),
+		Status:               prior.Status,
+		WaitForCompletion:    types.BoolValue(true),
+		Triggers:             prior.Triggers,
+	}
+	if !prior.Id.IsNull() {
+		current.InvalidationIds = types.ListValueMust(types.StringType, []attr.Value{prior.Id})
+	}
+	var diags diag.Diagnostics
+	current.Timeouts, diags = upgradeTimeouts(ctx, prior.Timeouts, "create", "read", "update", "delete")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &current)...)
+}
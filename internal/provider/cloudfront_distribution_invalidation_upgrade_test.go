@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"testing"
+	"time"
+)
+
+func TestUpgradeCloudfrontDistributionInvalidationStateV0(t *testing.T) {
+	ctx := context.Background()
+	priorSchema := cloudfrontDistributionInvalidationSchemaV0(ctx)
+	prior := tfsdk.State{Schema: *priorSchema, Raw: tftypes.NewValue(priorSchema.Type().TerraformType(ctx), nil)}
+	paths, _ := types.SetValueFrom(ctx, types.StringType, []string{"/*"})
+	if diags := prior.Set(ctx, &CloudfrontDistributionInvalidationModelV0{
+		Id:             types.StringValue("I1"),
+		DistributionId: types.StringValue("E1111111111111"),
+		Paths:          paths,
+		Status:         types.StringValue("Completed"),
+		Triggers:       types.MapNull(types.StringType),
+		Timeouts: timeouts.Value{Object: types.ObjectValueMust(map[string]attr.Type{"create": types.StringType}, map[string]attr.Value{
+			"create": types.StringValue("10m"),
+		})},
+	}); diags.HasError() {
+		t.Fatalf("unexpected error setting prior state: %v", diags)
+	}
+
+	r := &CloudfrontDistributionInvalidationResource{}
+	schemaResponse := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+	response := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgradeCloudfrontDistributionInvalidationStateV0(ctx, resource.UpgradeStateRequest{State: &prior}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error upgrading state: %v", response.Diagnostics)
+	}
+
+	var current CloudfrontDistributionInvalidationModel
+	if diags := response.State.Get(ctx, &current); diags.HasError() {
+		t.Fatalf("unexpected error reading upgraded state: %v", diags)
+	}
+	ids := make([]string, 0)
+	current.InvalidationIds.ElementsAs(ctx, &ids, false)
+	if current.Id.ValueString() != "I1" || len(ids) != 1 || ids[0] != "I1" {
+		t.Errorf("expected id I1 to be the only invalidation ID, got %s and %v", current.Id, ids)
+	}
+	if current.DistributionId.ValueString() != "E1111111111111" || current.Status.ValueString() != "Completed" || !current.Paths.Equal(paths) {
+		t.Errorf("expected prior attributes to be preserved, got distribution_id=%s status=%s paths=%s", current.DistributionId, current.Status, current.Paths)
+	}
+	if !current.WaitForCompletion.ValueBool() || current.AlwaysInvalidate.ValueBool() || current.InvalidateOnDestroy.ValueBool() {
+		t.Errorf("expected defaults for new attributes, got wait_for_completion=%s always_invalidate=%s invalidate_on_destroy=%s",
+			current.WaitForCompletion, current.AlwaysInvalidate, current.InvalidateOnDestroy)
+	}
+	if create, _ := current.Timeouts.Create(ctx, time.Minute); create != 10*time.Minute {
+		t.Errorf("expected the create timeout to be preserved, got %s", create)
+	}
+}
@@ -3,28 +3,41 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/aws/aws-sdk-go-v2/aws"
 	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
 	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
-	"regexp"
+	"maps"
+	"sort"
 	"strings"
 	"time"
 )
 
-var _ resource.Resource = &CloudfrontDistributionInvalidationsResource{}
+var (
+	_ resource.Resource                   = &CloudfrontDistributionInvalidationsResource{}
+	_ resource.ResourceWithModifyPlan     = &CloudfrontDistributionInvalidationsResource{}
+	_ resource.ResourceWithValidateConfig = &CloudfrontDistributionInvalidationsResource{}
+	_ resource.ResourceWithUpgradeState   = &CloudfrontDistributionInvalidationsResource{}
+)
 
 type CloudfrontDistributionInvalidationsResource struct {
 	client *conns.Client
@@ -35,12 +48,63 @@ func NewCloudfrontDistributionInvalidationsResource() resource.Resource {
 }
 
 type CloudfrontDistributionInvalidationsModel struct {
-	Id              types.String   `tfsdk:"id"`
-	DistributionIds types.Set      `tfsdk:"distribution_ids"`
-	Paths           types.Set      `tfsdk:"paths"`
-	Statuses        types.Map      `tfsdk:"statuses"`
-	Triggers        types.Map      `tfsdk:"triggers"`
-	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	Id                      types.String   `tfsdk:"id"`
+	AccountRoles            types.Map      `tfsdk:"account_roles"`
+	AlwaysInvalidate        types.Bool     `tfsdk:"always_invalidate"`
+	Arns                    types.Map      `tfsdk:"arns"`
+	CallerReferences        types.Map      `tfsdk:"caller_references"`
+	CollapseThreshold       types.Int64    `tfsdk:"collapse_threshold"`
+	CreateTimes             types.Map      `tfsdk:"create_times"`
+	DistributionIds         types.Set      `tfsdk:"distribution_ids"`
+	Distributions           types.Map      `tfsdk:"distributions"`
+	DistributionSelector    types.Object   `tfsdk:"distribution_selector"`
+	EffectivePaths          types.Map      `tfsdk:"effective_paths"`
+	InvalidateOnDestroy     types.Bool     `tfsdk:"invalidate_on_destroy"`
+	InvalidationIds         types.Map      `tfsdk:"invalidation_ids"`
+	Invalidations           types.Map      `tfsdk:"invalidations"`
+	MaxConcurrency          types.Int64    `tfsdk:"max_concurrency"`
+	Paths                   types.Set      `tfsdk:"paths"`
+	PathsFile               types.String   `tfsdk:"paths_file"`
+	PathsFileHash           types.String   `tfsdk:"paths_file_hash"`
+	ResolvedDistributionIds types.Set      `tfsdk:"resolved_distribution_ids"`
+	Statuses                types.Map      `tfsdk:"statuses"`
+	WaitForCompletion       types.Bool     `tfsdk:"wait_for_completion"`
+	Triggers                types.Map      `tfsdk:"triggers"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+}
+
+// CloudfrontDistributionInvalidationsDistributionModel configures the invalidation for a single distribution
+type CloudfrontDistributionInvalidationsDistributionModel struct {
+	AssumeRole types.Object `tfsdk:"assume_role"`
+	Paths      types.Set    `tfsdk:"paths"`
+}
+
+func (m CloudfrontDistributionInvalidationsDistributionModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"assume_role": types.ObjectType{AttrTypes: DistributionAssumeRoleModel{}.AttrTypes()},
+		"paths":       types.SetType{ElemType: types.StringType},
+	}
+}
+
+// CloudfrontDistributionInvalidationsResultModel is the result of the invalidations for a single distribution
+type CloudfrontDistributionInvalidationsResultModel struct {
+	Id              types.String      `tfsdk:"id"`
+	Arn             types.String      `tfsdk:"arn"`
+	CallerReference types.String      `tfsdk:"caller_reference"`
+	InvalidationIds types.List        `tfsdk:"invalidation_ids"`
+	Status          types.String      `tfsdk:"status"`
+	CreateTime      timetypes.RFC3339 `tfsdk:"create_time"`
+}
+
+func (m CloudfrontDistributionInvalidationsResultModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":               types.StringType,
+		"arn":              types.StringType,
+		"caller_reference": types.StringType,
+		"invalidation_ids": types.ListType{ElemType: types.StringType},
+		"status":           types.StringType,
+		"create_time":      timetypes.RFC3339Type{},
+	}
 }
 
 func (r *CloudfrontDistributionInvalidationsResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -50,36 +114,190 @@ func (r *CloudfrontDistributionInvalidationsResource) Metadata(ctx context.Conte
 func (r *CloudfrontDistributionInvalidationsResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		MarkdownDescription: "",
+		// Version 1 records the invalidations for each distribution in `invalidations` instead of the `;`-joined `id` (see UpgradeState)
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
-			"distribution_ids": schema.SetAttribute{
+			"account_roles":     accountRolesAttribute(),
+			"always_invalidate": alwaysInvalidateAttribute(),
+			"arns": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "An ARN-like identifier of the first invalidation indexed by the Cloudfront Distribution ID. " +
+					"CloudFront does not assign ARNs to invalidations; this is synthesized to correlate with CloudTrail events.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"caller_references": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "The caller reference of the first invalidation indexed by the Cloudfront Distribution ID. " +
+					"This is a hash of the distribution ID, `effective_paths`, `triggers`, and a value generated when the resource is created, " +
+					"so that retrying a failed distribution does not create a duplicate invalidation but a replaced resource creates a new one.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"collapse_threshold": schema.Int64Attribute{
+				MarkdownDescription: "When set, paths are collapsed to minimize the number of billable paths: " +
+					"when more than `collapse_threshold` paths share a directory, they are replaced with a wildcard for the directory (e.g. `/assets/*`). " +
+					"The paths that are submitted to CloudFront are exposed in `effective_paths`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"create_times": schema.MapAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "A list of Cloudfront Distribution IDs where an invalidation will be created.",
-				Required:            true,
+				MarkdownDescription: "The date and time (RFC3339) the first invalidation was created indexed by the Cloudfront Distribution ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"distribution_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "A list of Cloudfront Distribution IDs or ARNs where an invalidation of `paths` will be created. " +
+					"At least one of `distribution_ids`, `distributions`, or `distribution_selector` must be configured.",
+				Optional: true,
 				PlanModifiers: []planmodifier.Set{
-					setplanmodifier.RequiresReplace(),
+					setplanmodifier.RequiresReplaceIf(distributionIdsRequireReplace,
+						"Changing the distributions forces new invalidations.",
+						"Changing the distributions forces new invalidations."),
 				},
 				Validators: []validator.Set{
 					setvalidator.ValueStringsAre(
-						stringvalidator.LengthAtLeast(1),
+						DistributionIdValidator{},
 					),
 				},
 			},
+			"distributions": schema.MapNestedAttribute{
+				MarkdownDescription: "A map of Cloudfront Distribution IDs or ARNs where an invalidation will be created to the paths to invalidate on each distribution. " +
+					"Distributions that omit `paths` invalidate the resource's `paths`. " +
+					"Changing the distributions or their `paths` forces new invalidations; changing `assume_role` does not.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(distributionsRequireReplace,
+						"Changing the distributions or their `paths` forces new invalidations.",
+						"Changing the distributions or their `paths` forces new invalidations."),
+				},
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(DistributionIdValidator{}),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"assume_role": distributionAssumeRoleSchema("The IAM role to assume to invalidate the distribution, e.g. when the distribution is in another account. " +
+							"Takes precedence over `account_roles`."),
+						"paths": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "A list of paths to invalidate on the distribution. Each path *must* start with `/` and may only contain `*` as the last character.",
+							Optional:            true,
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(InvalidationPathValidator{}),
+							},
+						},
+					},
+				},
+			},
+			"distribution_selector": distributionSelectorSchema("Selects Cloudfront Distributions where an invalidation of `paths` will be created. " +
+				"The IDs of the selected distributions are recorded in `resolved_distribution_ids`."),
+			"effective_paths": schema.MapAttribute{
+				ElementType:         types.SetType{ElemType: types.StringType},
+				MarkdownDescription: "The paths that were submitted to CloudFront after URL-encoding and collapsing indexed by the Cloudfront Distribution ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"invalidate_on_destroy": invalidateOnDestroyAttribute(),
+			"invalidation_ids": schema.MapAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "The IDs of every invalidation that was created indexed by the Cloudfront Distribution ID. " +
+					"When `paths` exceed CloudFront's in-progress quotas, they are split into multiple invalidations that are created one after another.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"invalidations": schema.MapNestedAttribute{
+				MarkdownDescription: "The result of the invalidations indexed by the Cloudfront Distribution ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the first invalidation.",
+							Computed:            true,
+						},
+						"arn": schema.StringAttribute{
+							MarkdownDescription: "An ARN-like identifier of the first invalidation (see `arns`).",
+							Computed:            true,
+						},
+						"caller_reference": schema.StringAttribute{
+							MarkdownDescription: "The caller reference of the first invalidation (see `caller_references`).",
+							Computed:            true,
+						},
+						"invalidation_ids": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The IDs of every invalidation that was created for the distribution.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the invalidation. This is `Completed` only once every invalidation has completed, or `Skipped` when the provider skips invalidations.",
+							Computed:            true,
+						},
+						"create_time": schema.StringAttribute{
+							CustomType:          timetypes.RFC3339Type{},
+							MarkdownDescription: "The date and time (RFC3339) the first invalidation was created.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of distributions to invalidate at once. " +
+					"Defaults to the provider's `max_concurrency`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"paths": schema.SetAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "A list of paths to invalidate. Each path *must* start with `/`.",
-				Required:            true,
+				ElementType: types.StringType,
+				MarkdownDescription: "A list of paths to invalidate on `distribution_ids`, the distributions selected by `distribution_selector`, and any of `distributions` that omit `paths`. " +
+					"Each path *must* start with `/` and may only contain `*` as the last character. " +
+					"Characters that CloudFront requires to be URL-encoded (e.g. spaces) are encoded before the invalidation is created. " +
+					"Conflicts with `paths_file`.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Set{
+					usePathsStateWhenNotConfigured{},
 					setplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.Set{
-					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "")),
+					setvalidator.ValueStringsAre(InvalidationPathValidator{}),
+				},
+			},
+			"paths_file": pathsFileAttribute(
+				stringvalidator.ConflictsWith(path.MatchRoot("paths")),
+			),
+			"paths_file_hash": pathsFileHashAttribute(),
+			"resolved_distribution_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the Cloudfront Distributions that matched `distribution_selector` when the invalidations were created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"statuses": schema.MapAttribute{
 				ElementType: types.StringType,
-				Description: "The status of each invalidation indexed by the Cloudfront Distribution ID.",
-				Computed:    true,
+				Description: "The status of each invalidation indexed by the Cloudfront Distribution ID. " +
+					"Distributions that could not be invalidated have a status of `Failed`, and distributions without an invalidation in CloudFront have a status of `NotFound`; both are retried on the next apply.",
+				Computed: true,
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.UseStateForUnknown(),
 				},
@@ -88,11 +306,18 @@ func (r *CloudfrontDistributionInvalidationsResource) Schema(ctx context.Context
 				ElementType:         types.StringType,
 				MarkdownDescription: "A map of triggers that, when changed, will force Terraform to create a new invalidation.",
 				Optional:            true,
-				Computed:            true,
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.RequiresReplace(),
 				},
 			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, Terraform waits for every invalidation to complete before finishing the apply. " +
+					"When `false`, Terraform finishes as soon as CloudFront accepts the invalidations and `status` is refreshed on subsequent reads. " +
+					"Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the invalidations.",
 				Computed:            true,
@@ -104,6 +329,9 @@ func (r *CloudfrontDistributionInvalidationsResource) Schema(ctx context.Context
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
 			}),
 		},
 	}
@@ -119,7 +347,7 @@ func (r *CloudfrontDistributionInvalidationsResource) Configure(ctx context.Cont
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *conns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -135,25 +363,56 @@ func (r *CloudfrontDistributionInvalidationsResource) Create(ctx context.Context
 		return
 	}
 
-	createTimeout, diags := data.Timeouts.Create(ctx, 30*time.Minute)
+	createTimeout, diags := data.Timeouts.Create(ctx, r.client.DefaultTimeouts.CreateTimeout())
+	response.Diagnostics.Append(diags...)
+	opts, diags := r.createOptions(ctx, data, createTimeout)
+	response.Diagnostics.Append(diags...)
+	nonce, diags := newCreateNonce(ctx, response.Private)
+	response.Diagnostics.Append(diags...)
+	opts.Triggers = cloudfront.WithTrigger(opts.Triggers, cloudfront.TriggerCreateNonce, nonce)
+	data.ResolvedDistributionIds = types.SetNull(types.StringType)
+	if !data.DistributionSelector.IsNull() {
+		resolvedIds, diags := resolveDistributionSelector(ctx, r.client, data.DistributionSelector)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		data.ResolvedDistributionIds, diags = types.SetValueFrom(ctx, types.StringType, resolvedIds)
+		response.Diagnostics.Append(diags...)
+	}
+	paths, diags := r.distributionPaths(ctx, data)
 	response.Diagnostics.Append(diags...)
-	distributionIds := make([]string, 0)
-	response.Diagnostics.Append(data.DistributionIds.ElementsAs(ctx, &distributionIds, false)...)
-	paths := make([]string, 0)
-	response.Diagnostics.Append(data.Paths.ElementsAs(ctx, &paths, false)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
-
-	result, diags := cloudfront.CreateInvalidations(ctx, r.client, distributionIds, paths, createTimeout)
+	distributionIds := sortedKeys(paths)
+	clients, diags := r.clients(ctx, data)
 	response.Diagnostics.Append(diags...)
-	response.Diagnostics.Append(r.setResult(ctx, &data, distributionIds, result)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	if data.Triggers.IsUnknown() {
-		data.Triggers = types.MapNull(types.StringType)
+	results, diags := cloudfront.CreateInvalidations(ctx, clients, paths, opts)
+	failed := cloudfront.FailedDistributionIds(results)
+	if len(failed) > 0 && len(failed) == len(distributionIds) {
+		response.Diagnostics.Append(diags...)
+		return
+	}
+	if len(failed) > 0 {
+		// Returning an error from Create would taint the resource and replace the invalidations that succeeded
+		// Instead, the failures are reported as warnings and the failed distributions are retried on the next apply
+		response.Diagnostics.Append(errorsAsWarnings(diags)...)
+		response.Diagnostics.AddWarning("Unable to create AWS Cloudfront Invalidations for some distributions",
+			fmt.Sprintf("Invalidations failed for distributions: %s. They will be retried on the next apply.", strings.Join(failed, ", ")))
+	} else {
+		response.Diagnostics.Append(diags...)
+	}
+
+	invals, failedIds := splitInvalidationResults(results)
+	response.Diagnostics.Append(r.setResult(ctx, &data, clients, distributionIds, invals, failedIds, nil)...)
+	response.Diagnostics.Append(r.setEffectivePaths(ctx, &data, paths, opts.CollapseThreshold)...)
+	if response.Diagnostics.HasError() {
+		return
 	}
 
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
@@ -165,53 +424,651 @@ func (r *CloudfrontDistributionInvalidationsResource) Read(ctx context.Context,
 	if response.Diagnostics.HasError() {
 		return
 	}
+	readTimeout, diags := data.Timeouts.Read(ctx, r.client.DefaultTimeouts.ReadTimeout())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	paths, diags := r.distributionPaths(ctx, data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	distributionIds := sortedKeys(paths)
 
-	distributionIds := make([]string, 0)
-	response.Diagnostics.Append(data.DistributionIds.ElementsAs(ctx, &distributionIds, false)...)
+	ids, diags := r.findInvalidationIds(ctx, data)
+	response.Diagnostics.Append(diags...)
+	clients, diags := r.clients(ctx, data)
+	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	ids := map[string]string{}
-	for i, id := range strings.Split(data.Id.ValueString(), ";") {
-		if i >= len(distributionIds) {
-			break
+	// A distribution whose role cannot be assumed keeps its prior result so that it does not fail the refresh
+	kept := map[string]bool{}
+	for distributionId, failed := range clients.Failed {
+		if len(ids[distributionId]) == 0 {
+			continue
+		}
+		kept[distributionId] = true
+		delete(ids, distributionId)
+		details := make([]string, 0)
+		for _, d := range failed.Errors() {
+			details = append(details, d.Summary()+": "+d.Detail())
 		}
-		ids[distributionIds[i]] = id
+		response.Diagnostics.AddWarning(fmt.Sprintf("Unable to refresh AWS Cloudfront Invalidations (distribution %s)", distributionId),
+			fmt.Sprintf("The invalidations for this distribution were not refreshed and are kept from the previous state.\n\n%s", strings.Join(details, "\n")))
 	}
 
-	results, diags := cloudfront.FindInvalidations(ctx, r.client, ids)
+	results, diags := cloudfront.FindInvalidations(ctx, clients, ids, int(data.MaxConcurrency.ValueInt64()))
 	response.Diagnostics.Append(diags...)
-	response.Diagnostics.Append(r.setResult(ctx, &data, distributionIds, results)...)
+	response.Diagnostics.Append(r.setResult(ctx, &data, clients, distributionIds, results, r.failedDistributionIds(ctx, data), kept)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
+	// State upgraded from version 0 does not record the effective paths
+	if data.EffectivePaths.IsNull() {
+		actualPaths := map[string][]string{}
+		for distributionId, invals := range results {
+			actualPaths[distributionId] = cloudfront.InvalidationPaths(invals)
+		}
+		data.EffectivePaths, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, actualPaths)
+		response.Diagnostics.Append(diags...)
+	}
 
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
 func (r *CloudfrontDistributionInvalidationsResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data, state CloudfrontDistributionInvalidationsModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	targets := r.failedDistributionIds(ctx, state)
+	// Retrying the failed distributions reuses the caller references of the create
+	nonce, diags := createNonce(ctx, request.Private)
+	response.Diagnostics.Append(diags...)
+	triggers := map[string]string{}
+	if nonce != "" {
+		triggers[cloudfront.TriggerCreateNonce] = nonce
+	}
+	if data.AlwaysInvalidate.ValueBool() {
+		paths, diags := r.distributionPaths(ctx, data)
+		response.Diagnostics.Append(diags...)
+		for distributionId := range paths {
+			targets[distributionId] = true
+		}
+		// The previous invalidations change on every apply, so the caller references do too
+		triggers[cloudfront.TriggerAlwaysInvalidate] = state.Id.ValueString()
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if len(targets) > 0 {
+		response.Diagnostics.Append(r.reinvalidate(ctx, &data, state, targets, triggers)...)
+		if response.Diagnostics.HasError() && data.Statuses.IsUnknown() {
+			return
+		}
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan plans an update to retry the distributions that failed during a previous apply
+// When `always_invalidate` is enabled, an update is planned to invalidate every distribution again
+// When the plan will submit invalidations, a warning summarizes their impact (see cloudfront.EstimateImpact)
+func (r *CloudfrontDistributionInvalidationsResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		var state CloudfrontDistributionInvalidationsModel
+		response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+		if state.InvalidateOnDestroy.ValueBool() {
+			r.warnImpact(ctx, "When destroyed", state, nil, &response.Diagnostics)
+		}
+		return
+	}
+
+	var state, plan CloudfrontDistributionInvalidationsModel
+	var configPaths types.Set
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("paths"), &configPaths)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	var diags diag.Diagnostics
+	plan.Paths, plan.PathsFileHash, diags = planPathsFile(ctx, plan.PathsFile, configPaths)
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if request.State.Raw.IsNull() {
+		r.warnImpact(ctx, "When created", plan, nil, &response.Diagnostics)
+		return
+	}
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if pathsFileChanged(state.PathsFileHash, plan.PathsFileHash) {
+		response.RequiresReplace = append(response.RequiresReplace, path.Root("paths_file_hash"))
+	}
+	replace, diags := r.requiresReplace(ctx, state, plan)
+	response.Diagnostics.Append(diags...)
+	if replace {
+		if state.InvalidateOnDestroy.ValueBool() {
+			r.warnImpact(ctx, "When destroyed", state, nil, &response.Diagnostics)
+		}
+		r.warnImpact(ctx, "When replaced", plan, nil, &response.Diagnostics)
+		return
+	}
+	if plan.AlwaysInvalidate.ValueBool() {
+		r.warnImpact(ctx, "Because `always_invalidate` is enabled", plan, nil, &response.Diagnostics)
+	} else if failed := r.failedDistributionIds(ctx, state); len(failed) > 0 {
+		r.warnImpact(ctx, "To retry failed distributions", plan, failed, &response.Diagnostics)
+	} else {
+		return
+	}
+
+	plan.Id = types.StringUnknown()
+	plan.Arns = types.MapUnknown(types.StringType)
+	plan.CallerReferences = types.MapUnknown(types.StringType)
+	plan.CreateTimes = types.MapUnknown(types.StringType)
+	plan.EffectivePaths = types.MapUnknown(types.SetType{ElemType: types.StringType})
+	plan.InvalidationIds = types.MapUnknown(types.ListType{ElemType: types.StringType})
+	plan.Invalidations = types.MapUnknown(types.ObjectType{AttrTypes: CloudfrontDistributionInvalidationsResultModel{}.AttrTypes()})
+	plan.Statuses = types.MapUnknown(types.StringType)
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+// requiresReplace reports whether the distributions, their paths, or the triggers changed
+// Configuring a distribution by ID instead of ARN or changing its `assume_role` is an update
+func (r *CloudfrontDistributionInvalidationsResource) requiresReplace(ctx context.Context, state, plan CloudfrontDistributionInvalidationsModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if pathsFileChanged(state.PathsFileHash, plan.PathsFileHash) || plan.DistributionIds.IsUnknown() || plan.Distributions.IsUnknown() ||
+		!plan.DistributionSelector.Equal(state.DistributionSelector) || !plan.Paths.Equal(state.Paths) || !plan.Triggers.Equal(state.Triggers) {
+		return true, diags
+	}
+	stateIds, d := distributionIdSet(ctx, state.DistributionIds)
+	diags.Append(d...)
+	planIds, d := distributionIdSet(ctx, plan.DistributionIds)
+	diags.Append(d...)
+	statePaths, d := distributionPathsById(ctx, state.Distributions)
+	diags.Append(d...)
+	planPaths, d := distributionPathsById(ctx, plan.Distributions)
+	diags.Append(d...)
+	return !maps.Equal(stateIds, planIds) || !maps.EqualFunc(statePaths, planPaths, func(a, b types.Set) bool { return a.Equal(b) }), diags
+}
+
+// warnImpact adds a warning that summarizes the invalidations that will be submitted for model
+// If targets is not nil, only the targeted distributions are included
+func (r *CloudfrontDistributionInvalidationsResource) warnImpact(ctx context.Context, action string, model CloudfrontDistributionInvalidationsModel,
+	targets map[string]bool, diags *diag.Diagnostics) {
+	if model.Paths.IsUnknown() || model.DistributionIds.IsUnknown() || model.Distributions.IsUnknown() {
+		return
+	}
+	paths, d := r.distributionPaths(ctx, model)
+	if d.HasError() {
+		return
+	}
+	if targets != nil {
+		for distributionId := range paths {
+			if !targets[distributionId] {
+				delete(paths, distributionId)
+			}
+		}
+	}
+
+	note := ""
+	if !model.DistributionSelector.IsNull() && (model.ResolvedDistributionIds.IsNull() || model.ResolvedDistributionIds.IsUnknown()) {
+		note = "This does not include the distributions that will be selected by `distribution_selector`, which each invalidate `paths`."
+	}
+	impact := cloudfront.EstimateImpact(paths, int(model.CollapseThreshold.ValueInt64()))
+	diags.Append(impactWarning(r.client, action, impact, note)...)
 }
 
 func (r *CloudfrontDistributionInvalidationsResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data CloudfrontDistributionInvalidationsModel
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() || !data.InvalidateOnDestroy.ValueBool() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.client.DefaultTimeouts.DeleteTimeout())
+	response.Diagnostics.Append(diags...)
+	opts, diags := r.createOptions(ctx, data, deleteTimeout)
+	response.Diagnostics.Append(diags...)
+	paths, diags := r.distributionPaths(ctx, data)
+	response.Diagnostics.Append(diags...)
+	clients, diags := r.clients(ctx, data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	opts.Triggers = cloudfront.WithTrigger(opts.Triggers, cloudfront.TriggerInvalidateOnDestroy, data.Id.ValueString())
+
+	_, diags = cloudfront.CreateInvalidations(ctx, clients, paths, opts)
+	response.Diagnostics.Append(diags...)
 }
 
-func (r *CloudfrontDistributionInvalidationsResource) setResult(ctx context.Context, model *CloudfrontDistributionInvalidationsModel,
-	distributionIds []string, results map[string]*cftypes.Invalidation) diag.Diagnostics {
+// reinvalidate creates the invalidations for the targeted distributions during an update
+// These are the distributions that failed during a previous apply or, with `always_invalidate`, every distribution
+// The invalidations for every other distribution are refreshed from state so they are not created again
+// extraTriggers are added to the triggers of the new invalidations
+func (r *CloudfrontDistributionInvalidationsResource) reinvalidate(ctx context.Context, data *CloudfrontDistributionInvalidationsModel,
+	state CloudfrontDistributionInvalidationsModel, targets map[string]bool, extraTriggers map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	ids := make([]string, 0)
+	updateTimeout, d := data.Timeouts.Update(ctx, r.client.DefaultTimeouts.UpdateTimeout())
+	diags.Append(d...)
+	opts, d := r.createOptions(ctx, *data, updateTimeout)
+	diags.Append(d...)
+	for key, value := range extraTriggers {
+		opts.Triggers = cloudfront.WithTrigger(opts.Triggers, key, value)
+	}
+	paths, d := r.distributionPaths(ctx, *data)
+	diags.Append(d...)
+	ids, d := r.findInvalidationIds(ctx, state)
+	diags.Append(d...)
+	clients, d := r.clients(ctx, *data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	distributionIds := sortedKeys(paths)
+
+	retryPaths := map[string][]string{}
+	for distributionId := range targets {
+		if cur, ok := paths[distributionId]; ok {
+			retryPaths[distributionId] = cur
+		}
+		delete(ids, distributionId)
+	}
+	existing, d := cloudfront.FindInvalidations(ctx, clients, ids, int(data.MaxConcurrency.ValueInt64()))
+	diags.Append(d...)
+	results, d := cloudfront.CreateInvalidations(ctx, clients, retryPaths, opts)
+	diags.Append(d...)
+	if stillFailed := cloudfront.FailedDistributionIds(results); len(stillFailed) > 0 {
+		diags.AddError("Unable to create AWS Cloudfront Invalidations for some distributions",
+			fmt.Sprintf("Invalidations failed for distributions: %s. They will be retried on the next apply.", strings.Join(stillFailed, ", ")))
+	}
+
+	invals, failedIds := splitInvalidationResults(results)
+	for distributionId, cur := range existing {
+		invals[distributionId] = cur
+	}
+	diags.Append(r.setResult(ctx, data, clients, distributionIds, invals, failedIds, nil)...)
+	diags.Append(r.setEffectivePaths(ctx, data, paths, opts.CollapseThreshold)...)
+	return diags
+}
+
+// ValidateConfig ensures that every distribution has paths to invalidate
+// and that `distributions` does not configure the same distribution by both ID and ARN
+func (r *CloudfrontDistributionInvalidationsResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data CloudfrontDistributionInvalidationsModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DistributionIds.IsNull() && data.Distributions.IsNull() && data.DistributionSelector.IsNull() {
+		response.Diagnostics.AddError("Missing Attribute Configuration",
+			"At least one of `distribution_ids`, `distributions`, or `distribution_selector` must be configured.")
+		return
+	}
+	if !data.Distributions.IsNull() && !data.Distributions.IsUnknown() {
+		keys := map[string]string{}
+		for _, key := range sortedKeys(data.Distributions.Elements()) {
+			distributionId := cloudfront.DistributionId(key)
+			if other, ok := keys[distributionId]; ok {
+				response.Diagnostics.AddAttributeError(path.Root("distributions").AtMapKey(key), "Duplicate Distribution",
+					fmt.Sprintf("%q and %q refer to the same distribution %q; configure it once.", other, key, distributionId))
+			}
+			keys[distributionId] = key
+		}
+	}
+	if !data.Paths.IsNull() || !data.PathsFile.IsNull() {
+		return
+	}
+	if !data.DistributionIds.IsNull() {
+		response.Diagnostics.AddAttributeError(path.Root("distribution_ids"), "Missing Attribute Configuration",
+			"`paths` or `paths_file` must be configured to invalidate `distribution_ids`.")
+	}
+	if !data.DistributionSelector.IsNull() {
+		response.Diagnostics.AddAttributeError(path.Root("distribution_selector"), "Missing Attribute Configuration",
+			"`paths` or `paths_file` must be configured to invalidate the distributions selected by `distribution_selector`.")
+	}
+	if data.Distributions.IsNull() || data.Distributions.IsUnknown() {
+		return
+	}
+	distributions := map[string]CloudfrontDistributionInvalidationsDistributionModel{}
+	response.Diagnostics.Append(data.Distributions.ElementsAs(ctx, &distributions, false)...)
+	for distributionId, distribution := range distributions {
+		if distribution.Paths.IsNull() {
+			response.Diagnostics.AddAttributeError(path.Root("distributions").AtMapKey(distributionId).AtName("paths"), "Missing Attribute Configuration",
+				fmt.Sprintf("`paths` must be configured for distribution %q because the resource does not configure `paths` or `paths_file`.", distributionId))
+		}
+	}
+}
+
+// distributionPaths resolves the paths to invalidate indexed by distribution ID
+// Distributions configured by ARN are indexed by their ID
+// Each of `distribution_ids` and `resolved_distribution_ids` uses `paths`
+// Each of `distributions` uses its own paths and falls back to `paths`; a distribution configured more than once uses its entry in `distributions`
+func (r *CloudfrontDistributionInvalidationsResource) distributionPaths(ctx context.Context, data CloudfrontDistributionInvalidationsModel) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	shared := make([]string, 0)
+	if !data.Paths.IsNull() {
+		diags.Append(data.Paths.ElementsAs(ctx, &shared, false)...)
+	}
+
+	result := map[string][]string{}
+	for _, ids := range []types.Set{data.DistributionIds, data.ResolvedDistributionIds} {
+		if ids.IsNull() || ids.IsUnknown() {
+			continue
+		}
+		distributionIds := make([]string, 0)
+		diags.Append(ids.ElementsAs(ctx, &distributionIds, false)...)
+		for _, distributionId := range distributionIds {
+			result[cloudfront.DistributionId(distributionId)] = shared
+		}
+	}
+	if !data.Distributions.IsNull() {
+		distributions := map[string]CloudfrontDistributionInvalidationsDistributionModel{}
+		diags.Append(data.Distributions.ElementsAs(ctx, &distributions, false)...)
+		for key, distribution := range distributions {
+			distributionId := cloudfront.DistributionId(key)
+			result[distributionId] = shared
+			if !distribution.Paths.IsNull() {
+				cur := make([]string, 0)
+				diags.Append(distribution.Paths.ElementsAs(ctx, &cur, false)...)
+				result[distributionId] = cur
+			}
+		}
+	}
+	return result, diags
+}
+
+// clients builds the client for each distribution (see distributionClients)
+// A distribution in `distributions` assumes its `assume_role`
+// Otherwise, a distribution configured by ARN assumes the role for its account in `account_roles`
+func (r *CloudfrontDistributionInvalidationsResource) clients(ctx context.Context, data CloudfrontDistributionInvalidationsModel) (cloudfront.Clients, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	accountRoles := map[string]string{}
+	if !data.AccountRoles.IsNull() && !data.AccountRoles.IsUnknown() {
+		diags.Append(data.AccountRoles.ElementsAs(ctx, &accountRoles, false)...)
+	}
+	roles := map[string]awsbase.AssumeRole{}
+	addAccountRole := func(value string) {
+		if roleArn, ok := accountRoles[cloudfront.DistributionAccountId(value)]; ok {
+			roles[cloudfront.DistributionId(value)] = awsbase.AssumeRole{RoleARN: roleArn}
+		}
+	}
+	if !data.DistributionIds.IsNull() && !data.DistributionIds.IsUnknown() {
+		distributionIds := make([]string, 0)
+		diags.Append(data.DistributionIds.ElementsAs(ctx, &distributionIds, false)...)
+		for _, value := range distributionIds {
+			addAccountRole(value)
+		}
+	}
+	if !data.Distributions.IsNull() && !data.Distributions.IsUnknown() {
+		distributions := map[string]CloudfrontDistributionInvalidationsDistributionModel{}
+		diags.Append(data.Distributions.ElementsAs(ctx, &distributions, false)...)
+		for key, distribution := range distributions {
+			if distribution.AssumeRole.IsNull() {
+				addAccountRole(key)
+				continue
+			}
+			var assumeRole DistributionAssumeRoleModel
+			diags.Append(distribution.AssumeRole.As(ctx, &assumeRole, basetypes.ObjectAsOptions{})...)
+			roles[cloudfront.DistributionId(key)] = assumeRole.AssumeRole()
+		}
+	}
+	if diags.HasError() {
+		return cloudfront.Clients{Default: r.client}, diags
+	}
+
+	clients, d := distributionClients(ctx, r.client, roles)
+	diags.Append(d...)
+	return clients, diags
+}
+
+// distributionsRequireReplace requires replacement when the distributions or their paths change
+// Changing `assume_role` only affects the credentials used to manage the invalidations, so it does not require replacement
+// Configuring the same distribution by ID instead of ARN (or vice versa) does not require replacement either
+func distributionsRequireReplace(ctx context.Context, request planmodifier.MapRequest, response *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+	if request.PlanValue.IsUnknown() {
+		response.RequiresReplace = true
+		return
+	}
+	state, diags := distributionPathsById(ctx, request.StateValue)
+	response.Diagnostics.Append(diags...)
+	plan, diags := distributionPathsById(ctx, request.PlanValue)
+	response.Diagnostics.Append(diags...)
+	response.RequiresReplace = !maps.EqualFunc(state, plan, func(a, b types.Set) bool { return a.Equal(b) })
+}
+
+// distributionPathsById returns the `paths` of each of `distributions` indexed by distribution ID
+func distributionPathsById(ctx context.Context, value types.Map) (map[string]types.Set, diag.Diagnostics) {
+	result := map[string]types.Set{}
+	if value.IsNull() || value.IsUnknown() {
+		return result, nil
+	}
+	distributions := map[string]CloudfrontDistributionInvalidationsDistributionModel{}
+	diags := value.ElementsAs(ctx, &distributions, false)
+	for key, distribution := range distributions {
+		result[cloudfront.DistributionId(key)] = distribution.Paths
+	}
+	return result, diags
+}
+
+func (r *CloudfrontDistributionInvalidationsResource) createOptions(ctx context.Context, data CloudfrontDistributionInvalidationsModel,
+	timeout time.Duration) (cloudfront.CreateOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := cloudfront.CreateOptions{
+		CreateTimeout:     timeout,
+		WaitForCompletion: data.WaitForCompletion.ValueBool(),
+		MaxConcurrency:    int(data.MaxConcurrency.ValueInt64()),
+		CollapseThreshold: int(data.CollapseThreshold.ValueInt64()),
+	}
+	if !data.Triggers.IsNull() && !data.Triggers.IsUnknown() {
+		diags.Append(data.Triggers.ElementsAs(ctx, &opts.Triggers, false)...)
+	}
+	return opts, diags
+}
+
+// failedDistributionIds returns the distributions recorded in state that must be invalidated again
+// These failed during a previous apply or have no invalidation in CloudFront
+func (r *CloudfrontDistributionInvalidationsResource) failedDistributionIds(ctx context.Context, data CloudfrontDistributionInvalidationsModel) map[string]bool {
+	failed := map[string]bool{}
+	if data.Statuses.IsNull() || data.Statuses.IsUnknown() {
+		return failed
+	}
 	statuses := map[string]string{}
+	data.Statuses.ElementsAs(ctx, &statuses, false)
+	for distributionId, status := range statuses {
+		if status == cloudfront.StatusFailed || status == cloudfront.StatusNotFound {
+			failed[distributionId] = true
+		}
+	}
+	return failed
+}
+
+// findInvalidationIds retrieves the invalidation IDs for each distribution from state
+// State from before `invalidations` existed is converted by UpgradeState
+func (r *CloudfrontDistributionInvalidationsResource) findInvalidationIds(ctx context.Context, data CloudfrontDistributionInvalidationsModel) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ids := map[string][]string{}
+	if data.Invalidations.IsNull() || data.Invalidations.IsUnknown() {
+		return ids, diags
+	}
+	results := map[string]CloudfrontDistributionInvalidationsResultModel{}
+	diags.Append(data.Invalidations.ElementsAs(ctx, &results, false)...)
+	for distributionId, result := range results {
+		cur := make([]string, 0)
+		diags.Append(result.InvalidationIds.ElementsAs(ctx, &cur, false)...)
+		ids[distributionId] = cur
+	}
+	return ids, diags
+}
+
+// setResult records the invalidations for each distribution (see setInvalidations)
+// Distributions in failed are recorded with a status of `Failed` so they are retried on the next apply
+// Distributions in kept could not be refreshed, so their prior result is recorded as-is
+func (r *CloudfrontDistributionInvalidationsResource) setResult(ctx context.Context, model *CloudfrontDistributionInvalidationsModel, clients cloudfront.Clients,
+	distributionIds []string, results map[string][]*cftypes.Invalidation, failed map[string]bool, kept map[string]bool) diag.Diagnostics {
+
+	var diags, d diag.Diagnostics
+	prior := map[string]CloudfrontDistributionInvalidationsResultModel{}
+	if !model.Invalidations.IsNull() && !model.Invalidations.IsUnknown() {
+		diags.Append(model.Invalidations.ElementsAs(ctx, &prior, false)...)
+	}
+	ids := make([]string, 0)
+	invalidations := map[string]CloudfrontDistributionInvalidationsResultModel{}
 	for _, distributionId := range distributionIds {
-		cur, ok := results[distributionId]
-		if !ok {
-			ids = append(ids, "")
-			statuses[distributionId] = "unknown"
+		if result, ok := prior[distributionId]; ok && kept[distributionId] {
+			invalidations[distributionId] = result
+			if !result.Id.IsNull() {
+				ids = append(ids, result.Id.ValueString())
+			}
+			continue
+		}
+		cur := results[distributionId]
+		result := CloudfrontDistributionInvalidationsResultModel{
+			Id:              types.StringNull(),
+			Arn:             types.StringNull(),
+			CallerReference: types.StringNull(),
+			CreateTime:      timetypes.NewRFC3339Null(),
+		}
+		result.InvalidationIds, d = types.ListValueFrom(ctx, types.StringType, cloudfront.InvalidationIds(cur))
+		diags.Append(d...)
+		if len(cur) > 0 {
+			first := cur[0]
+			result.Id = types.StringPointerValue(first.Id)
+			result.CreateTime = timetypes.NewRFC3339TimePointerValue(first.CreateTime)
+			if first.InvalidationBatch != nil {
+				result.CallerReference = types.StringPointerValue(first.InvalidationBatch.CallerReference)
+			}
+			// A skipped invalidation is found without its caller reference and create time
+			if cur, ok := prior[distributionId]; ok && cur.Id.Equal(result.Id) {
+				if result.CreateTime.IsNull() {
+					result.CreateTime = cur.CreateTime
+				}
+				if result.CallerReference.IsNull() {
+					result.CallerReference = cur.CallerReference
+				}
+			}
+		}
+		switch {
+		case failed[distributionId]:
+			result.Status = types.StringValue(cloudfront.StatusFailed)
+		case len(cur) == 0:
+			result.Status = types.StringValue(cloudfront.StatusNotFound)
+		default:
+			result.Status = types.StringValue(cloudfront.AggregateStatus(cur))
+			// Reuse the ARN recorded for the same invalidation
+			if cur, ok := prior[distributionId]; ok && !cur.Arn.IsNull() && cur.Id.Equal(result.Id) {
+				result.Arn = cur.Arn
+				break
+			}
+			invalArn, d := cloudfront.InvalidationArn(ctx, clients.For(distributionId), distributionId, result.Id.ValueString())
+			diags.Append(d...)
+			result.Arn = types.StringPointerValue(invalArn)
+		}
+		invalidations[distributionId] = result
+		if !result.Id.IsNull() {
+			ids = append(ids, result.Id.ValueString())
 		}
-		ids = append(ids, aws.ToString(cur.Id))
-		statuses[distributionId] = aws.ToString(cur.Status)
 	}
 	model.Id = types.StringValue(strings.Join(ids, ";"))
+	diags.Append(setInvalidations(ctx, model, invalidations)...)
+	return diags
+}
+
+// setInvalidations records the result for each distribution in `invalidations`
+// The attributes that are indexed by distribution ID (e.g. `statuses`) are derived from the results so that they are always consistent
+func setInvalidations(ctx context.Context, model *CloudfrontDistributionInvalidationsModel, invalidations map[string]CloudfrontDistributionInvalidationsResultModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	statuses := map[string]string{}
+	invalidationIds := map[string]types.List{}
+	arns := map[string]string{}
+	callerReferences := map[string]string{}
+	createTimes := map[string]string{}
+	for distributionId, result := range invalidations {
+		invalidationIds[distributionId] = result.InvalidationIds
+		if !result.Status.IsNull() {
+			statuses[distributionId] = result.Status.ValueString()
+		}
+		if !result.Arn.IsNull() {
+			arns[distributionId] = result.Arn.ValueString()
+		}
+		if !result.CallerReference.IsNull() {
+			callerReferences[distributionId] = result.CallerReference.ValueString()
+		}
+		if !result.CreateTime.IsNull() {
+			createTimes[distributionId] = result.CreateTime.ValueString()
+		}
+	}
+	model.Statuses, d = types.MapValueFrom(ctx, types.StringType, statuses)
+	diags.Append(d...)
+	model.InvalidationIds, d = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, invalidationIds)
+	diags.Append(d...)
+	model.Arns, d = types.MapValueFrom(ctx, types.StringType, arns)
+	diags.Append(d...)
+	model.CallerReferences, d = types.MapValueFrom(ctx, types.StringType, callerReferences)
+	diags.Append(d...)
+	model.CreateTimes, d = types.MapValueFrom(ctx, types.StringType, createTimes)
+	diags.Append(d...)
+	model.Invalidations, d = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: CloudfrontDistributionInvalidationsResultModel{}.AttrTypes()}, invalidations)
+	diags.Append(d...)
+	return diags
+}
+
+// setEffectivePaths records the paths that are submitted to CloudFront for each distribution
+func (r *CloudfrontDistributionInvalidationsResource) setEffectivePaths(ctx context.Context, model *CloudfrontDistributionInvalidationsModel,
+	paths map[string][]string, collapseThreshold int) diag.Diagnostics {
+	effectivePaths := map[string][]string{}
+	for distributionId, cur := range paths {
+		effectivePaths[distributionId] = cloudfront.EffectivePaths(cur, collapseThreshold)
+	}
 	var diags diag.Diagnostics
-	model.Statuses, diags = types.MapValueFrom(ctx, types.StringType, statuses)
+	model.EffectivePaths, diags = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, effectivePaths)
 	return diags
 }
+
+func splitInvalidationResults(results map[string]cloudfront.InvalidationResult) (map[string][]*cftypes.Invalidation, map[string]bool) {
+	invals := map[string][]*cftypes.Invalidation{}
+	failed := map[string]bool{}
+	for distributionId, result := range results {
+		invals[distributionId] = result.Invalidations
+		if result.Failed() {
+			failed[distributionId] = true
+		}
+	}
+	return invals, failed
+}
+
+func errorsAsWarnings(in diag.Diagnostics) diag.Diagnostics {
+	var out diag.Diagnostics
+	for _, d := range in {
+		out.AddWarning(d.Summary(), d.Detail())
+	}
+	return out
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+)
+
+var (
+	_ datasource.DataSource = &CloudfrontDistributionInvalidationsDataSource{}
+)
+
+type CloudfrontDistributionInvalidationsDataSource struct {
+	client *conns.Client
+}
+
+func NewCloudfrontDistributionInvalidationsDataSource() datasource.DataSource {
+	return &CloudfrontDistributionInvalidationsDataSource{}
+}
+
+type CloudfrontDistributionInvalidationsDataSourceModel struct {
+	Id             types.String      `tfsdk:"id"`
+	CreatedAfter   timetypes.RFC3339 `tfsdk:"created_after"`
+	CreatedBefore  timetypes.RFC3339 `tfsdk:"created_before"`
+	DistributionId types.String      `tfsdk:"distribution_id"`
+	Ids            types.List        `tfsdk:"ids"`
+	IncludePaths   types.Bool        `tfsdk:"include_paths"`
+	InProgress     types.Bool        `tfsdk:"in_progress"`
+	Invalidations  types.List        `tfsdk:"invalidations"`
+	MaxResults     types.Int64       `tfsdk:"max_results"`
+	Status         types.String      `tfsdk:"status"`
+	Timeouts       timeouts.Value    `tfsdk:"timeouts"`
+}
+
+// CloudfrontDistributionInvalidationsDataSourceItemModel is a single invalidation found by the data source
+type CloudfrontDistributionInvalidationsDataSourceItemModel struct {
+	Id         types.String      `tfsdk:"id"`
+	CreateTime timetypes.RFC3339 `tfsdk:"create_time"`
+	Paths      types.Set         `tfsdk:"paths"`
+	Status     types.String      `tfsdk:"status"`
+}
+
+func (m CloudfrontDistributionInvalidationsDataSourceItemModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":          types.StringType,
+		"create_time": timetypes.RFC3339Type{},
+		"paths":       types.SetType{ElemType: types.StringType},
+		"status":      types.StringType,
+	}
+}
+
+func (d *CloudfrontDistributionInvalidationsDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_cloudfront_distribution_invalidations"
+}
+
+func (d *CloudfrontDistributionInvalidationsDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Lists the invalidations for a Cloudfront Distribution, most recent first.",
+
+		Attributes: map[string]schema.Attribute{
+			"created_after": schema.StringAttribute{
+				CustomType:          timetypes.RFC3339Type{},
+				MarkdownDescription: "Only include invalidations created at or after this date and time (RFC3339).",
+				Optional:            true,
+			},
+			"created_before": schema.StringAttribute{
+				CustomType:          timetypes.RFC3339Type{},
+				MarkdownDescription: "Only include invalidations created before this date and time (RFC3339).",
+				Optional:            true,
+			},
+			"distribution_id": schema.StringAttribute{
+				MarkdownDescription: "The Cloudfront Distribution ID or ARN to list invalidations for.",
+				Required:            true,
+				Validators: []validator.String{
+					DistributionIdValidator{},
+				},
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the invalidations that were found, most recent first.",
+				Computed:            true,
+			},
+			"include_paths": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, the paths of each invalidation are retrieved. " +
+					"This requires an additional API request per invalidation, so consider limiting the results with `max_results`. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"in_progress": schema.BoolAttribute{
+				MarkdownDescription: "Whether any of the invalidations that were found are still in progress.",
+				Computed:            true,
+			},
+			"invalidations": schema.ListNestedAttribute{
+				MarkdownDescription: "The invalidations that were found, most recent first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the invalidation.",
+							Computed:            true,
+						},
+						"create_time": schema.StringAttribute{
+							CustomType:          timetypes.RFC3339Type{},
+							MarkdownDescription: "The date and time (RFC3339) the invalidation was created.",
+							Computed:            true,
+						},
+						"paths": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The paths that were invalidated. This is only set when `include_paths` is `true`.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the invalidation (`InProgress` or `Completed`).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of invalidations to return. The most recent invalidations are returned.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only include invalidations with this status (`InProgress` or `Completed`).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(cloudfront.StatusInProgress, cloudfront.StatusCompleted),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Cloudfront Distribution ID.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *CloudfrontDistributionInvalidationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*conns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *conns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CloudfrontDistributionInvalidationsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data CloudfrontDistributionInvalidationsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	filter := cloudfront.ListFilter{
+		Status:     data.Status.ValueString(),
+		MaxResults: int(data.MaxResults.ValueInt64()),
+	}
+	if !data.CreatedAfter.IsNull() {
+		createdAfter, diags := data.CreatedAfter.ValueRFC3339Time()
+		response.Diagnostics.Append(diags...)
+		filter.CreatedAfter = createdAfter
+	}
+	if !data.CreatedBefore.IsNull() {
+		createdBefore, diags := data.CreatedBefore.ValueRFC3339Time()
+		response.Diagnostics.Append(diags...)
+		filter.CreatedBefore = createdBefore
+	}
+	readTimeout, diags := data.Timeouts.Read(ctx, d.client.DefaultTimeouts.ReadTimeout())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	distributionId := distributionId(data.DistributionId)
+	summaries, diags := cloudfront.ListInvalidations(ctx, d.client, distributionId, filter)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make([]string, 0, len(summaries))
+	items := make([]CloudfrontDistributionInvalidationsDataSourceItemModel, 0, len(summaries))
+	inProgress := false
+	for _, summary := range summaries {
+		id := aws.ToString(summary.Id)
+		ids = append(ids, id)
+		if aws.ToString(summary.Status) == cloudfront.StatusInProgress {
+			inProgress = true
+		}
+		item := CloudfrontDistributionInvalidationsDataSourceItemModel{
+			Id:         types.StringValue(id),
+			CreateTime: timetypes.NewRFC3339TimePointerValue(summary.CreateTime),
+			Paths:      types.SetNull(types.StringType),
+			Status:     types.StringPointerValue(summary.Status),
+		}
+		if data.IncludePaths.ValueBool() {
+			inval, diags := cloudfront.FindInvalidation(ctx, d.client, distributionId, id)
+			response.Diagnostics.Append(diags...)
+			if response.Diagnostics.HasError() {
+				return
+			}
+			if inval != nil {
+				item.Paths, diags = types.SetValueFrom(ctx, types.StringType, cloudfront.InvalidationPaths([]*cftypes.Invalidation{inval}))
+				response.Diagnostics.Append(diags...)
+			}
+		}
+		items = append(items, item)
+	}
+
+	data.Id = types.StringValue(distributionId)
+	data.InProgress = types.BoolValue(inProgress)
+	data.Ids, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	response.Diagnostics.Append(diags...)
+	data.Invalidations, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: CloudfrontDistributionInvalidationsDataSourceItemModel{}.AttrTypes()}, items)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestAccDistributionInvalidationsDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	cdnId := testAccCreateCdn(t, "test", "www.example.com")
+	config1 := providerConfig + fmt.Sprintf(`
+resource "awsex_cloudfront_distribution_invalidation" "test" {
+  distribution_id = %[1]q
+  paths           = ["/*"]
+}
+
+data "awsex_cloudfront_distribution_invalidations" "test" {
+  distribution_id = awsex_cloudfront_distribution_invalidation.test.distribution_id
+  status          = "Completed"
+  include_paths   = true
+  max_results     = 1
+
+  depends_on = [awsex_cloudfront_distribution_invalidation.test]
+}
+`, cdnId)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsex_cloudfront_distribution_invalidations.test", "invalidations.#", "1"),
+					resource.TestCheckResourceAttr("data.awsex_cloudfront_distribution_invalidations.test", "in_progress", "false"),
+					resource.TestCheckResourceAttrPair("data.awsex_cloudfront_distribution_invalidations.test", "ids.0", "awsex_cloudfront_distribution_invalidation.test", "id"),
+					resource.TestCheckResourceAttr("data.awsex_cloudfront_distribution_invalidations.test", "invalidations.0.paths.0", "/*"),
+				),
+			},
+		},
+	})
+}
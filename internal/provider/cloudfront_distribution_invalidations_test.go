@@ -1,8 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront/cloudfronttest"
+	"strings"
 	"testing"
 )
 
@@ -31,8 +43,309 @@ resource "awsex_cloudfront_distribution_invalidations" "test" {
 					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", "statuses.%", "2"),
 					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", fmt.Sprintf("statuses.%s", cdn1Id), "Completed"),
 					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", fmt.Sprintf("statuses.%s", cdn2Id), "Completed"),
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", "invalidations.%", "2"),
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", fmt.Sprintf("invalidations.%s.status", cdn1Id), "Completed"),
+					resource.TestCheckResourceAttrSet("awsex_cloudfront_distribution_invalidations.test", fmt.Sprintf("invalidations.%s.id", cdn2Id)),
 				),
 			},
 		},
 	})
 }
+
+func TestAccDistributionInvalidationsPerDistributionPaths(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	cdn1Id := testAccCreateCdn(t, "test1", "www.example1.com")
+	cdn2Id := testAccCreateCdn(t, "test2", "www.example2.com")
+	config1 := providerConfig + fmt.Sprintf(`
+resource "awsex_cloudfront_distribution_invalidations" "test" {
+  paths = ["/*"]
+
+  distributions = {
+    %[1]q = {
+      paths = ["/tenant1/*"]
+    }
+    %[2]q = {}
+  }
+}
+`, cdn1Id, cdn2Id)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", "invalidations.%", "2"),
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", fmt.Sprintf("statuses.%s", cdn1Id), "Completed"),
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_invalidations.test", fmt.Sprintf("statuses.%s", cdn2Id), "Completed"),
+				),
+			},
+		},
+	})
+}
+
+var testDistributionType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"assume_role": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"external_id":  tftypes.String,
+		"role_arn":     tftypes.String,
+		"session_name": tftypes.String,
+	}},
+	"paths": tftypes.Set{ElementType: tftypes.String},
+}}
+
+// testDistributions builds `distributions` from the paths of each distribution and an optional role for each distribution
+func testDistributions(paths map[string][]string, roles map[string]string) tftypes.Value {
+	roleType := testDistributionType.AttributeTypes["assume_role"].(tftypes.Object)
+	elements := map[string]tftypes.Value{}
+	for key, cur := range paths {
+		assumeRole := tftypes.NewValue(roleType, nil)
+		if roleArn, ok := roles[key]; ok {
+			assumeRole = testObjectValue(roleType, map[string]tftypes.Value{"role_arn": testString(roleArn)})
+		}
+		elements[key] = testObjectValue(testDistributionType, map[string]tftypes.Value{
+			"assume_role": assumeRole,
+			"paths":       testStringSet(cur...),
+		})
+	}
+	return tftypes.NewValue(tftypes.Map{ElementType: testDistributionType}, elements)
+}
+
+func testCloudfrontDistributionInvalidationsConfig() map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"distribution_ids": testStringSet("E1111111111111"),
+		"distributions": testDistributions(map[string][]string{
+			"arn:aws:cloudfront::123456789012:distribution/E2222222222222": {"/index.html"},
+		}, nil),
+		"paths": testStringSet("/*"),
+	}
+}
+
+func testCloudfrontDistributionInvalidationsState() map[string]tftypes.Value {
+	state := testCloudfrontDistributionInvalidationsConfig()
+	state["always_invalidate"] = testBool(false)
+	state["id"] = testString("I1;I2")
+	state["invalidate_on_destroy"] = testBool(false)
+	state["statuses"] = testStringMap(map[string]string{"E1111111111111": "Completed", "E2222222222222": "Completed"})
+	state["wait_for_completion"] = testBool(true)
+	return state
+}
+
+func TestCloudfrontDistributionInvalidationsPlanReplace(t *testing.T) {
+	tests := map[string]struct {
+		config      map[string]tftypes.Value
+		wantReplace bool
+	}{
+		"unchanged": {},
+		"invalidate_on_destroy": {
+			config: map[string]tftypes.Value{"invalidate_on_destroy": testBool(true)},
+		},
+		"always_invalidate": {
+			config: map[string]tftypes.Value{"always_invalidate": testBool(true)},
+		},
+		"wait_for_completion": {
+			config: map[string]tftypes.Value{"wait_for_completion": testBool(false)},
+		},
+		"max_concurrency": {
+			config: map[string]tftypes.Value{"max_concurrency": tftypes.NewValue(tftypes.Number, 2)},
+		},
+		"account_roles": {
+			config: map[string]tftypes.Value{"account_roles": testStringMap(map[string]string{"123456789012": "arn:aws:iam::123456789012:role/invalidator"})},
+		},
+		"assume_role": {
+			config: map[string]tftypes.Value{"distributions": testDistributions(map[string][]string{
+				"arn:aws:cloudfront::123456789012:distribution/E2222222222222": {"/index.html"},
+			}, map[string]string{
+				"arn:aws:cloudfront::123456789012:distribution/E2222222222222": "arn:aws:iam::123456789012:role/invalidator",
+			})},
+		},
+		"distribution_ids by arn": {
+			config: map[string]tftypes.Value{"distribution_ids": testStringSet("arn:aws:cloudfront::123456789012:distribution/E1111111111111")},
+		},
+		"distribution_ids": {
+			config:      map[string]tftypes.Value{"distribution_ids": testStringSet("E1111111111111", "E3333333333333")},
+			wantReplace: true,
+		},
+		"distributions by id": {
+			config: map[string]tftypes.Value{"distributions": testDistributions(map[string][]string{
+				"E2222222222222": {"/index.html"},
+			}, nil)},
+		},
+		"triggers": {
+			config:      map[string]tftypes.Value{"triggers": testStringMap(map[string]string{"version": "2"})},
+			wantReplace: true,
+		},
+		"distribution paths": {
+			config: map[string]tftypes.Value{"distributions": testDistributions(map[string][]string{
+				"arn:aws:cloudfront::123456789012:distribution/E2222222222222": {"/about.html"},
+			}, nil)},
+			wantReplace: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testCloudfrontDistributionInvalidationsConfig()
+			for key, value := range test.config {
+				config[key] = value
+			}
+			plan := testPlanResourceChange(t, "awsex_cloudfront_distribution_invalidations", testCloudfrontDistributionInvalidationsState(), config)
+			if replace := len(plan.RequiresReplace) > 0; replace != test.wantReplace {
+				t.Errorf("expected replace %t, got %v", test.wantReplace, plan.RequiresReplace)
+			}
+			if detail, _ := plan.Warning("AWS Cloudfront Invalidations planned"); strings.HasPrefix(detail, "When replaced") != test.wantReplace {
+				t.Errorf("expected a warning for the replacement %t, got %q", test.wantReplace, detail)
+			}
+		})
+	}
+}
+
+func TestCloudfrontDistributionInvalidationsSetResult(t *testing.T) {
+	ctx := context.Background()
+	client := &conns.Client{BaseConfig: awsbase.Config{Region: "us-east-1", SkipCredsValidation: true, SkipRequestingAccountId: true}}
+	r := &CloudfrontDistributionInvalidationsResource{client: client}
+	results := map[string][]*cftypes.Invalidation{
+		"E1111111111111": {{Id: aws.String("I1"), Status: aws.String(cloudfront.StatusInProgress)}},
+		"E2222222222222": {},
+	}
+	failed := map[string]bool{"E2222222222222": true}
+
+	var model CloudfrontDistributionInvalidationsModel
+	diags := r.setResult(ctx, &model, cloudfront.Clients{Default: client}, []string{"E1111111111111", "E2222222222222", "E3333333333333"}, results, failed, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	statuses := map[string]string{}
+	model.Statuses.ElementsAs(ctx, &statuses, false)
+	want := map[string]string{
+		"E1111111111111": cloudfront.StatusInProgress,
+		"E2222222222222": cloudfront.StatusFailed,
+		"E3333333333333": cloudfront.StatusNotFound,
+	}
+	for distributionId, status := range want {
+		if statuses[distributionId] != status {
+			t.Errorf("expected status %q for %s, got %q", status, distributionId, statuses[distributionId])
+		}
+	}
+	if len(model.Invalidations.Elements()) != 3 {
+		t.Errorf("expected an entry in invalidations for every distribution, got %s", model.Invalidations)
+	}
+	retry := r.failedDistributionIds(ctx, model)
+	if len(retry) != 2 || !retry["E2222222222222"] || !retry["E3333333333333"] {
+		t.Errorf("expected the failed and missing distributions to be retried, got %v", retry)
+	}
+}
+
+func TestCloudfrontDistributionInvalidationsSetResultKeepsArn(t *testing.T) {
+	ctx := context.Background()
+	client := &conns.Client{BaseConfig: awsbase.Config{Region: "us-east-1", SkipCredsValidation: true, SkipRequestingAccountId: true}}
+	r := &CloudfrontDistributionInvalidationsResource{client: client}
+	distributionIds := []string{"E1111111111111", "E2222222222222"}
+	results := map[string][]*cftypes.Invalidation{
+		"E1111111111111": {{Id: aws.String("I1"), Status: aws.String(cloudfront.StatusCompleted)}},
+		"E2222222222222": {{Id: aws.String("I2"), Status: aws.String(cloudfront.StatusCompleted)}},
+	}
+	var model CloudfrontDistributionInvalidationsModel
+	if diags := r.setResult(ctx, &model, cloudfront.Clients{Default: client}, distributionIds, results, nil, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// Replace the recorded ARNs so that a lookup can be told apart from the prior value
+	prior := map[string]CloudfrontDistributionInvalidationsResultModel{}
+	model.Invalidations.ElementsAs(ctx, &prior, false)
+	for distributionId, result := range prior {
+		result.Arn = types.StringValue("prior")
+		prior[distributionId] = result
+	}
+	setInvalidations(ctx, &model, prior)
+
+	results["E2222222222222"] = []*cftypes.Invalidation{{Id: aws.String("I3"), Status: aws.String(cloudfront.StatusInProgress)}}
+	if diags := r.setResult(ctx, &model, cloudfront.Clients{Default: client}, distributionIds, results, nil, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	arns := map[string]string{}
+	model.Arns.ElementsAs(ctx, &arns, false)
+	if arns["E1111111111111"] != "prior" {
+		t.Errorf("expected the ARN of an unchanged invalidation to be kept, got %q", arns["E1111111111111"])
+	}
+	if !strings.HasSuffix(arns["E2222222222222"], "distribution/E2222222222222/invalidation/I3") {
+		t.Errorf("expected the ARN of a new invalidation to be looked up, got %q", arns["E2222222222222"])
+	}
+}
+
+func TestCloudfrontDistributionInvalidationsReplace(t *testing.T) {
+	server := cloudfronttest.NewServer(t)
+	server.SetError("E2222222222222", "AccessDenied")
+	provider := newTestProviderServer(t, server.Client())
+	config := map[string]tftypes.Value{
+		"distribution_ids":    testStringSet("E1111111111111", "E2222222222222"),
+		"paths":               testStringSet("/*"),
+		"wait_for_completion": testBool(false),
+	}
+	created := provider.Apply(t, "awsex_cloudfront_distribution_invalidations", nil, nil, config)
+	created.CheckErrors(t)
+
+	// A retried apply reuses the caller reference of the failed distribution, even if the previous attempt was not saved
+	server.SetError("E2222222222222", "")
+	for range 2 {
+		provider.Apply(t, "awsex_cloudfront_distribution_invalidations", created.Attributes(t), created.Private, config).CheckErrors(t)
+	}
+	if got := server.Invalidations("E2222222222222"); got != 1 {
+		t.Errorf("expected the retries to create 1 invalidation for E2222222222222, got %d", got)
+	}
+
+	// Replace the resource with the same configuration
+	provider.Apply(t, "awsex_cloudfront_distribution_invalidations", nil, nil, config).CheckErrors(t)
+	for _, distributionId := range []string{"E1111111111111", "E2222222222222"} {
+		if got := server.Invalidations(distributionId); got != 2 {
+			t.Errorf("expected the replacement to create a new invalidation for %s, got %d invalidations", distributionId, got)
+		}
+	}
+}
+
+func TestCloudfrontDistributionInvalidationsReadFailedRole(t *testing.T) {
+	server := cloudfronttest.NewServer(t)
+	client := server.Client()
+	client.BaseConfig.AccessKey = servicemocks.MockStaticAccessKey
+	client.BaseConfig.SecretKey = servicemocks.MockStaticSecretKey
+	client.BaseConfig.StsEndpoint = testStsServer(t).URL
+	client.BaseConfig.EC2MetadataServiceEnableState = imds.ClientDisabled
+	client.BaseConfig.MaxRetries = 1
+	provider := newTestProviderServer(t, client)
+	created := provider.Apply(t, "awsex_cloudfront_distribution_invalidations", nil, nil, map[string]tftypes.Value{
+		"distribution_ids":    testStringSet("E1111111111111"),
+		"distributions":       testDistributions(map[string][]string{"E2222222222222": {"/*"}}, nil),
+		"paths":               testStringSet("/*"),
+		"wait_for_completion": testBool(false),
+	})
+	created.CheckErrors(t)
+
+	// The role for E2222222222222 is configured after its invalidation was created, but it cannot be assumed
+	state := created.Attributes(t)
+	state["distributions"] = testDistributions(map[string][]string{"E2222222222222": {"/*"}}, map[string]string{"E2222222222222": testDeniedRoleArn})
+	read := provider.Read(t, "awsex_cloudfront_distribution_invalidations", state, created.Private)
+	read.CheckErrors(t)
+	if _, ok := read.Warning("Unable to refresh AWS Cloudfront Invalidations (distribution E2222222222222)"); !ok {
+		t.Errorf("expected a warning for E2222222222222, got %v", read.Diagnostics)
+	}
+
+	var invalidations map[string]tftypes.Value
+	if err := read.Attributes(t)["invalidations"].As(&invalidations); err != nil {
+		t.Fatalf("unexpected error decoding invalidations: %s", err)
+	}
+	var prior map[string]tftypes.Value
+	state["invalidations"].As(&prior)
+	if !invalidations["E2222222222222"].Equal(prior["E2222222222222"]) {
+		t.Errorf("expected the prior result for E2222222222222 to be kept, got %s", invalidations["E2222222222222"])
+	}
+	var statuses map[string]tftypes.Value
+	var status string
+	read.Attributes(t)["statuses"].As(&statuses)
+	if statuses["E1111111111111"].As(&status); status != cloudfront.StatusCompleted {
+		t.Errorf("expected E1111111111111 to be refreshed, got status %q", status)
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// CloudfrontDistributionInvalidationsModelV0 is the state of awsex_cloudfront_distribution_invalidations before schema versioning (<= 0.1.3)
+type CloudfrontDistributionInvalidationsModelV0 struct {
+	Id              types.String   `tfsdk:"id"`
+	DistributionIds types.Set      `tfsdk:"distribution_ids"`
+	Paths           types.Set      `tfsdk:"paths"`
+	Statuses        types.Map      `tfsdk:"statuses"`
+	Triggers        types.Map      `tfsdk:"triggers"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *CloudfrontDistributionInvalidationsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   cloudfrontDistributionInvalidationsSchemaV0(ctx),
+			StateUpgrader: upgradeCloudfrontDistributionInvalidationsStateV0,
+		},
+	}
+}
+
+func cloudfrontDistributionInvalidationsSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"distribution_ids": schema.SetAttribute{ElementType: types.StringType, Required: true},
+			"paths":            schema.SetAttribute{ElementType: types.StringType, Required: true},
+			"statuses":         schema.MapAttribute{ElementType: types.StringType, Computed: true},
+			"triggers":         schema.MapAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+			"id":               schema.StringAttribute{Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// upgradeCloudfrontDistributionInvalidationsStateV0 converts the `;`-joined `id` into structured `invalidations`
+// Version 0 joined the invalidation IDs in the iteration order of `distribution_ids`, which is the order in state
+// Computed attributes that are not in the prior state are empty until the next refresh
+func upgradeCloudfrontDistributionInvalidationsStateV0(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	var prior CloudfrontDistributionInvalidationsModelV0
+	response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
+	distributionIds := make([]string, 0)
+	response.Diagnostics.Append(prior.DistributionIds.ElementsAs(ctx, &distributionIds, false)...)
+	statuses := map[string]string{}
+	if !prior.Statuses.IsNull() {
+		response.Diagnostics.Append(prior.Statuses.ElementsAs(ctx, &statuses, false)...)
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	invalidations := map[string]CloudfrontDistributionInvalidationsResultModel{}
+	ids := strings.Split(prior.Id.ValueString(), ";")
+	for i, distributionId := range distributionIds {
+		result := CloudfrontDistributionInvalidationsResultModel{
+			Id:              types.StringNull(),
+			Arn:             types.StringNull(),
+			CallerReference: types.StringNull(),
+			Status:          types.StringNull(),
+			CreateTime:      timetypes.NewRFC3339Null(),
+		}
+		invalidationIds := make([]string, 0)
+		if i < len(ids) && ids[i] != "" {
+			result.Id = types.StringValue(ids[i])
+			invalidationIds = append(invalidationIds, ids[i])
+		}
+		if status, ok := statuses[distributionId]; ok {
+			result.Status = types.StringValue(status)
+		}
+		result.InvalidationIds, diags = types.ListValueFrom(ctx, types.StringType, invalidationIds)
+		response.Diagnostics.Append(diags...)
+		invalidations[distributionId] = result
+	}
+
+	current := CloudfrontDistributionInvalidationsModel{
+		Id:                      prior.Id,
+		AccountRoles:            types.MapNull(types.StringType),
+		AlwaysInvalidate:        types.BoolValue(false),
+		CollapseThreshold:       types.Int64Null(),
+		DistributionIds:         prior.DistributionIds,
+		Distributions:           types.MapNull(types.ObjectType{AttrTypes: CloudfrontDistributionInvalidationsDistributionModel{}.AttrTypes()}),
+		DistributionSelector:    types.ObjectNull(DistributionSelectorModel{}.AttrTypes()),
+		EffectivePaths:          types.MapNull(types.SetType{ElemType: types.StringType}),
+		InvalidateOnDestroy:     types.BoolValue(false),
+		MaxConcurrency:          types.Int64Null(),
+		Paths:                   prior.Paths,
+		PathsFile:               types.StringNull(),
+		PathsFileHash:           types.StringNull(),
+		ResolvedDistributionIds: types.SetNull(types.StringType),
+		WaitForCompletion:       types.BoolValue(true),
+		Triggers:                prior.Triggers,
+	}
+	response.Diagnostics.Append(setInvalidations(ctx, &current, invalidations)...)
+	current.Timeouts, diags = upgradeTimeouts(ctx, prior.Timeouts, "create", "read", "update", "delete")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &current)...)
+}
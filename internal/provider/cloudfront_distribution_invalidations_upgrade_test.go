@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"testing"
+)
+
+func TestUpgradeCloudfrontDistributionInvalidationsStateV0(t *testing.T) {
+	ctx := context.Background()
+	priorSchema := cloudfrontDistributionInvalidationsSchemaV0(ctx)
+	prior := tfsdk.State{Schema: *priorSchema, Raw: tftypes.NewValue(priorSchema.Type().TerraformType(ctx), nil)}
+	distributionIds, _ := types.SetValueFrom(ctx, types.StringType, []string{"E1111111111111", "E2222222222222"})
+	paths, _ := types.SetValueFrom(ctx, types.StringType, []string{"/*"})
+	statuses, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"E1111111111111": "Completed", "E2222222222222": "InProgress"})
+	if diags := prior.Set(ctx, &CloudfrontDistributionInvalidationsModelV0{
+		Id:              types.StringValue("I1;I2"),
+		DistributionIds: distributionIds,
+		Paths:           paths,
+		Statuses:        statuses,
+		Triggers:        types.MapNull(types.StringType),
+		Timeouts:        timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{"create": types.StringType})},
+	}); diags.HasError() {
+		t.Fatalf("unexpected error setting prior state: %v", diags)
+	}
+
+	r := &CloudfrontDistributionInvalidationsResource{}
+	schemaResponse := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+	response := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgradeCloudfrontDistributionInvalidationsStateV0(ctx, resource.UpgradeStateRequest{State: &prior}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error upgrading state: %v", response.Diagnostics)
+	}
+
+	var current CloudfrontDistributionInvalidationsModel
+	if diags := response.State.Get(ctx, &current); diags.HasError() {
+		t.Fatalf("unexpected error reading upgraded state: %v", diags)
+	}
+	got, diags := r.findInvalidationIds(ctx, current)
+	if diags.HasError() {
+		t.Fatalf("unexpected error finding invalidation ids: %v", diags)
+	}
+	want := map[string]string{"E1111111111111": "I1", "E2222222222222": "I2"}
+	for distributionId, id := range want {
+		if ids := got[distributionId]; len(ids) != 1 || ids[0] != id {
+			t.Errorf("expected invalidation ids [%s] for %s, got %v", id, distributionId, ids)
+		}
+	}
+	results := map[string]CloudfrontDistributionInvalidationsResultModel{}
+	current.Invalidations.ElementsAs(ctx, &results, false)
+	if status := results["E2222222222222"].Status.ValueString(); status != "InProgress" {
+		t.Errorf("expected status InProgress, got %q", status)
+	}
+	derived := map[string]string{}
+	current.Statuses.ElementsAs(ctx, &derived, false)
+	if len(derived) != 2 || derived["E1111111111111"] != "Completed" {
+		t.Errorf("expected statuses to be derived from invalidations, got %v", derived)
+	}
+	if !current.WaitForCompletion.ValueBool() || current.AlwaysInvalidate.ValueBool() {
+		t.Errorf("expected defaults for new attributes, got wait_for_completion=%s always_invalidate=%s", current.WaitForCompletion, current.AlwaysInvalidate)
+	}
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+	"time"
+)
+
+var (
+	_ resource.Resource               = &CloudfrontDistributionManifestInvalidationResource{}
+	_ resource.ResourceWithModifyPlan = &CloudfrontDistributionManifestInvalidationResource{}
+)
+
+type CloudfrontDistributionManifestInvalidationResource struct {
+	client *conns.Client
+}
+
+func NewCloudfrontDistributionManifestInvalidationResource() resource.Resource {
+	return &CloudfrontDistributionManifestInvalidationResource{}
+}
+
+type CloudfrontDistributionManifestInvalidationModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	DistributionId     types.String   `tfsdk:"distribution_id"`
+	InvalidatedPaths   types.Set      `tfsdk:"invalidated_paths"`
+	InvalidateOnCreate types.Bool     `tfsdk:"invalidate_on_create"`
+	InvalidationIds    types.List     `tfsdk:"invalidation_ids"`
+	Manifest           types.Map      `tfsdk:"manifest"`
+	Status             types.String   `tfsdk:"status"`
+	WaitForCompletion  types.Bool     `tfsdk:"wait_for_completion"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_cloudfront_distribution_manifest_invalidation"
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Invalidates only the paths of a Cloudfront Distribution whose content changed. " +
+			"The previous `manifest` is stored in state; when `manifest` changes, the paths that were added, removed, or whose hash changed are invalidated.",
+
+		Attributes: map[string]schema.Attribute{
+			"distribution_id": schema.StringAttribute{
+				MarkdownDescription: "The Cloudfront Distribution ID or ARN where invalidations should be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(distributionIdRequiresReplace,
+						"Changing the distribution forces a new resource.",
+						"Changing the distribution forces a new resource."),
+				},
+				Validators: []validator.String{
+					DistributionIdValidator{},
+				},
+			},
+			"invalidated_paths": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The paths that were invalidated by the most recent change to `manifest`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"invalidate_on_create": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, every path in `manifest` is invalidated when the resource is created. " +
+					"When `false`, the initial `manifest` is only recorded and paths are invalidated once they change. " +
+					"Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"invalidation_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "The IDs of the invalidations created by the most recent change to `manifest`. " +
+					"When the changed paths exceed CloudFront's in-progress quotas, they are split into multiple invalidations that are created one after another.",
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"manifest": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "A map of URL paths to a hash of their content (e.g. from `filemd5` or S3 ETags). " +
+					"Each path *must* start with `/` and may only contain `*` as the last character.",
+				Required: true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(InvalidationPathValidator{}),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the invalidations created by the most recent change to `manifest`. " +
+					"This is `Completed` only once every invalidation has completed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, Terraform waits for every invalidation to complete before finishing the apply. " +
+					"When `false`, Terraform finishes as soon as CloudFront accepts the invalidations and `status` is refreshed on subsequent reads. " +
+					"Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Cloudfront Distribution ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*conns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *conns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data CloudfrontDistributionManifestInvalidationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, r.client.DefaultTimeouts.CreateTimeout())
+	response.Diagnostics.Append(diags...)
+	manifest := map[string]string{}
+	response.Diagnostics.Append(data.Manifest.ElementsAs(ctx, &manifest, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	changed := make([]string, 0)
+	if data.InvalidateOnCreate.ValueBool() {
+		changed = cloudfront.ManifestChanges(nil, manifest)
+	}
+	// There is no previous invalidation to chain from, so the caller reference only depends on the manifest
+	// A retried create reuses the invalidation, as does re-creating the resource with content that was already invalidated
+	triggers := cloudfront.ManifestTriggers(manifest, changed, nil)
+
+	data.Id = types.StringValue(distributionId(data.DistributionId))
+	response.Diagnostics.Append(r.invalidate(ctx, &data, changed, triggers, createTimeout)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data CloudfrontDistributionManifestInvalidationModel
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	readTimeout, diags := data.Timeouts.Read(ctx, r.client.DefaultTimeouts.ReadTimeout())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ids := make([]string, 0)
+	if !data.InvalidationIds.IsNull() {
+		response.Diagnostics.Append(data.InvalidationIds.ElementsAs(ctx, &ids, false)...)
+	}
+	if response.Diagnostics.HasError() || len(ids) == 0 {
+		return
+	}
+
+	// The manifest is the source of truth for this resource, so it is kept even if the invalidations no longer exist
+	invals, diags := cloudfront.FindInvalidationBatches(ctx, r.client, distributionId(data.DistributionId), ids)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if len(invals) > 0 {
+		data.Status = types.StringValue(cloudfront.AggregateStatus(invals))
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data, state CloudfrontDistributionManifestInvalidationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.client.DefaultTimeouts.UpdateTimeout())
+	response.Diagnostics.Append(diags...)
+	previous, current := map[string]string{}, map[string]string{}
+	response.Diagnostics.Append(state.Manifest.ElementsAs(ctx, &previous, false)...)
+	response.Diagnostics.Append(data.Manifest.ElementsAs(ctx, &current, false)...)
+	previousIds := make([]string, 0)
+	if !state.InvalidationIds.IsNull() {
+		response.Diagnostics.Append(state.InvalidationIds.ElementsAs(ctx, &previousIds, false)...)
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Only attributes that affect how invalidations are created changed, so they are saved as-is
+	changed := cloudfront.ManifestChanges(previous, current)
+	if len(changed) == 0 {
+		response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+		return
+	}
+
+	// If this fails, the previous manifest is kept so that the next apply retries the same change with the same caller reference
+	triggers := cloudfront.ManifestTriggers(current, changed, previousIds)
+	response.Diagnostics.Append(r.invalidate(ctx, &data, changed, triggers, updateTimeout)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan plans the paths that will be invalidated when `manifest` changes
+func (r *CloudfrontDistributionManifestInvalidationResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing to invalidate when destroying
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan CloudfrontDistributionManifestInvalidationModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if plan.Manifest.IsUnknown() || plan.InvalidateOnCreate.IsUnknown() {
+		plan.InvalidatedPaths = types.SetUnknown(types.StringType)
+		plan.InvalidationIds = types.ListUnknown(types.StringType)
+		plan.Status = types.StringUnknown()
+		response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	previous, current := map[string]string{}, map[string]string{}
+	response.Diagnostics.Append(plan.Manifest.ElementsAs(ctx, &current, false)...)
+	if !request.State.Raw.IsNull() {
+		var state CloudfrontDistributionManifestInvalidationModel
+		response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+		response.Diagnostics.Append(state.Manifest.ElementsAs(ctx, &previous, false)...)
+	} else if !plan.InvalidateOnCreate.ValueBool() {
+		previous = current
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	changed := cloudfront.ManifestChanges(previous, current)
+	if !request.State.Raw.IsNull() && len(changed) == 0 {
+		return
+	}
+	if len(changed) > 0 {
+		action := "Because `manifest` changed"
+		if request.State.Raw.IsNull() {
+			action = "When created"
+		}
+		impact := cloudfront.EstimateImpact(map[string][]string{distributionId(plan.DistributionId): changed}, 0)
+		response.Diagnostics.Append(impactWarning(r.client, action, impact, "")...)
+	}
+	var diags diag.Diagnostics
+	plan.InvalidatedPaths, diags = types.SetValueFrom(ctx, types.StringType, changed)
+	response.Diagnostics.Append(diags...)
+	plan.InvalidationIds = types.ListUnknown(types.StringType)
+	plan.Status = types.StringUnknown()
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+func (r *CloudfrontDistributionManifestInvalidationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+}
+
+// invalidate creates the invalidations for the changed paths and records the result in model
+// If no paths changed, no invalidations are created and the result is empty
+func (r *CloudfrontDistributionManifestInvalidationResource) invalidate(ctx context.Context, model *CloudfrontDistributionManifestInvalidationModel,
+	changed []string, triggers map[string]string, timeout time.Duration) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	ids := make([]string, 0)
+	model.Status = types.StringNull()
+	if len(changed) > 0 {
+		opts := cloudfront.CreateOptions{
+			CreateTimeout:     timeout,
+			WaitForCompletion: model.WaitForCompletion.ValueBool(),
+			Triggers:          triggers,
+		}
+		invals, d := cloudfront.CreateInvalidation(ctx, r.client, distributionId(model.DistributionId), changed, opts)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		ids = cloudfront.InvalidationIds(invals)
+		model.Status = types.StringValue(cloudfront.AggregateStatus(invals))
+	}
+
+	model.InvalidatedPaths, d = types.SetValueFrom(ctx, types.StringType, changed)
+	diags.Append(d...)
+	model.InvalidationIds, d = types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	return diags
+}
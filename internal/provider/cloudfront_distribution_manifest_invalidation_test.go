@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestAccDistributionManifestInvalidation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	cdnId := testAccCreateCdn(t, "test", "www.example.com")
+	config := func(manifest string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "awsex_cloudfront_distribution_manifest_invalidation" "test" {
+  distribution_id = %[1]q
+  manifest        = %[2]s
+}
+`, cdnId, manifest)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`{ "/index.html" = "a", "/app.js" = "b", "/old.css" = "c" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "invalidated_paths.#", "3"),
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "status", "Completed"),
+				),
+			},
+			{
+				Config: config(`{ "/index.html" = "a", "/app.js" = "B", "/new.css" = "d" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "invalidated_paths.#", "3"),
+					resource.TestCheckTypeSetElemAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "invalidated_paths.*", "/app.js"),
+					resource.TestCheckTypeSetElemAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "invalidated_paths.*", "/new.css"),
+					resource.TestCheckTypeSetElemAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "invalidated_paths.*", "/old.css"),
+					resource.TestCheckResourceAttr("awsex_cloudfront_distribution_manifest_invalidation.test", "invalidation_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestCloudfrontDistributionManifestInvalidationPlanReplace(t *testing.T) {
+	state := map[string]tftypes.Value{
+		"distribution_id":      testString("E1111111111111"),
+		"id":                   testString("E1111111111111"),
+		"invalidate_on_create": testBool(true),
+		"invalidated_paths":    testStringSet("/index.html"),
+		"invalidation_ids":     testStringList("I1"),
+		"manifest":             testStringMap(map[string]string{"/index.html": "1"}),
+		"status":               testString("Completed"),
+		"wait_for_completion":  testBool(true),
+	}
+	tests := map[string]struct {
+		distributionId string
+		wantReplace    bool
+	}{
+		"unchanged":              {distributionId: "E1111111111111"},
+		"distribution_id by arn": {distributionId: "arn:aws:cloudfront::123456789012:distribution/E1111111111111"},
+		"distribution_id":        {distributionId: "E2222222222222", wantReplace: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"distribution_id": testString(test.distributionId),
+				"manifest":        testStringMap(map[string]string{"/index.html": "1"}),
+			}
+			plan := testPlanResourceChange(t, "awsex_cloudfront_distribution_manifest_invalidation", state, config)
+			if replace := len(plan.RequiresReplace) > 0; replace != test.wantReplace {
+				t.Errorf("expected replace %t, got %v", test.wantReplace, plan.RequiresReplace)
+			}
+		})
+	}
+}
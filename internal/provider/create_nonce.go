@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// createNonceKey is the private state key of the nonce generated when a resource is created
+const createNonceKey = "create_nonce"
+
+// privateState is the private state of a resource request or response
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// newCreateNonce generates the nonce for a new resource and records it in private state
+// Retries during the same create reuse the nonce, while a replaced resource gets a new one (see cloudfront.TriggerCreateNonce)
+func newCreateNonce(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	nonce := uuid.NewString()
+	value, err := json.Marshal(nonce)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to record create nonce", err.Error())
+		return "", diags
+	}
+	return nonce, private.SetKey(ctx, createNonceKey, value)
+}
+
+// createNonce returns the nonce recorded when the resource was created
+// It is empty for resources created before the nonce was recorded so that their caller references do not change
+func createNonce(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, createNonceKey)
+	if diags.HasError() || value == nil {
+		return "", diags
+	}
+	var nonce string
+	if err := json.Unmarshal(value, &nonce); err != nil {
+		diags.AddError("Unable to read create nonce", err.Error())
+	}
+	return nonce, diags
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"time"
+)
+
+func defaultTimeoutsSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "Default timeouts for resource operations. " +
+			"A resource's `timeouts` take precedence; operations without a timeout default to `30m`.",
+		Attributes: map[string]schema.Attribute{
+			"create": defaultTimeoutAttribute("creating a resource"),
+			"read":   defaultTimeoutAttribute("reading a resource or data source"),
+			"update": defaultTimeoutAttribute("updating a resource"),
+			"delete": defaultTimeoutAttribute("deleting a resource"),
+		},
+	}
+}
+
+func defaultTimeoutAttribute(operation string) schema.Attribute {
+	return schema.StringAttribute{
+		CustomType: timetypes.GoDurationType{},
+		Optional:   true,
+		Description: "The default timeout for " + operation + ". " +
+			"A string that can be parsed as a duration consisting of numbers and unit suffixes, such as \"30s\" or \"2h45m\".",
+	}
+}
+
+type AwsexDefaultTimeoutsModel struct {
+	Create timetypes.GoDuration `tfsdk:"create"`
+	Read   timetypes.GoDuration `tfsdk:"read"`
+	Update timetypes.GoDuration `tfsdk:"update"`
+	Delete timetypes.GoDuration `tfsdk:"delete"`
+}
+
+// Timeouts returns the default timeouts for conns.Client
+func (m *AwsexDefaultTimeoutsModel) Timeouts() conns.Timeouts {
+	if m == nil {
+		return conns.Timeouts{}
+	}
+
+	return conns.Timeouts{
+		Create: goDuration(m.Create),
+		Read:   goDuration(m.Read),
+		Update: goDuration(m.Update),
+		Delete: goDuration(m.Delete),
+	}
+}
+
+func goDuration(value timetypes.GoDuration) time.Duration {
+	// Validation will catch errors from this conversion
+	duration, _ := value.ValueGoDuration()
+	return duration
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+	"maps"
+)
+
+var (
+	_ validator.String = DistributionIdValidator{}
+)
+
+// DistributionIdValidator validates that a string value is a Cloudfront Distribution ID or ARN
+// An ARN must pass ArnValidator for the `cloudfront` service and a `distribution/` resource
+// An ID (including the ID in an ARN) must be 13-14 uppercase letters and digits
+type DistributionIdValidator struct {
+}
+
+func (v DistributionIdValidator) Description(ctx context.Context) string {
+	return "string must be a Cloudfront Distribution ID or ARN"
+}
+
+func (v DistributionIdValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v DistributionIdValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	value := request.ConfigValue.ValueString()
+	if arn.IsARN(value) {
+		arnValidator := ArnValidator{Service: "cloudfront", ResourcePrefix: cloudfront.DistributionResourcePrefix}
+		if errs := arnValidator.validate(request.Path.String(), value); len(errs) > 0 {
+			for _, err := range errs {
+				response.Diagnostics.AddAttributeError(request.Path, "Invalid Cloudfront Distribution ARN", err.Error())
+			}
+			return
+		}
+	}
+	if id := cloudfront.DistributionId(value); !cloudfront.IsDistributionId(id) {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid Cloudfront Distribution ID",
+			fmt.Sprintf("%q (%s) must be a Cloudfront Distribution ID (13-14 uppercase letters and digits) or ARN, got ID %q.", request.Path, value, id))
+	}
+}
+
+// distributionId returns the Cloudfront Distribution ID of a value that is configured as an ID or ARN
+func distributionId(value types.String) string {
+	return cloudfront.DistributionId(value.ValueString())
+}
+
+// distributionIdRequiresReplace requires replacement when the distribution changes
+// Configuring the same distribution by ID instead of ARN (or vice versa) does not require replacement
+func distributionIdRequiresReplace(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	response.RequiresReplace = request.PlanValue.IsUnknown() || distributionId(request.PlanValue) != distributionId(request.StateValue)
+}
+
+// distributionIdsRequireReplace requires replacement when the set of distributions changes
+// Configuring the same distributions by ID instead of ARN (or vice versa) does not require replacement
+func distributionIdsRequireReplace(ctx context.Context, request planmodifier.SetRequest, response *setplanmodifier.RequiresReplaceIfFuncResponse) {
+	if request.PlanValue.IsUnknown() {
+		response.RequiresReplace = true
+		return
+	}
+	state, diags := distributionIdSet(ctx, request.StateValue)
+	response.Diagnostics.Append(diags...)
+	plan, diags := distributionIdSet(ctx, request.PlanValue)
+	response.Diagnostics.Append(diags...)
+	response.RequiresReplace = !maps.Equal(state, plan)
+}
+
+// distributionIdSet returns the IDs of a set of distributions that are configured as IDs or ARNs
+func distributionIdSet(ctx context.Context, values types.Set) (map[string]bool, diag.Diagnostics) {
+	result := map[string]bool{}
+	if values.IsNull() || values.IsUnknown() {
+		return result, nil
+	}
+	cur := make([]string, 0)
+	diags := values.ElementsAs(ctx, &cur, false)
+	for _, value := range cur {
+		result[cloudfront.DistributionId(value)] = true
+	}
+	return result, diags
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+	"regexp"
+)
+
+// DistributionAssumeRoleModel configures the role that is assumed to invalidate a distribution in another account
+type DistributionAssumeRoleModel struct {
+	ExternalId  types.String `tfsdk:"external_id"`
+	RoleArn     types.String `tfsdk:"role_arn"`
+	SessionName types.String `tfsdk:"session_name"`
+}
+
+func (m DistributionAssumeRoleModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"external_id":  types.StringType,
+		"role_arn":     types.StringType,
+		"session_name": types.StringType,
+	}
+}
+
+// AssumeRole returns the awsbase configuration to assume the role
+func (m DistributionAssumeRoleModel) AssumeRole() awsbase.AssumeRole {
+	return awsbase.AssumeRole{
+		RoleARN:     m.RoleArn.ValueString(),
+		ExternalID:  m.ExternalId.ValueString(),
+		SessionName: m.SessionName.ValueString(),
+	}
+}
+
+var roleArnValidator = ArnValidator{Service: "iam", ResourcePrefix: "role/"}
+
+func distributionAssumeRoleSchema(description string) schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description + " The role is assumed using the provider's credentials, after any `assume_role` configured on the provider.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "A unique identifier that might be required when you assume a role in another account.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 1224),
+				},
+			},
+			"role_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the IAM role to assume.",
+				Required:            true,
+				Validators:          []validator.String{roleArnValidator},
+			},
+			"session_name": schema.StringAttribute{
+				MarkdownDescription: "The session name to use when assuming the role.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+		},
+	}
+}
+
+func accountRolesAttribute() schema.Attribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		MarkdownDescription: "A map of AWS account IDs to the ARN of the IAM role to assume to invalidate distributions in that account. " +
+			"This applies to distributions that are configured by ARN and do not configure their own `assume_role`; " +
+			"distributions configured by ID are invalidated with the provider's credentials.",
+		Optional: true,
+		Validators: []validator.Map{
+			mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^\d{12}$`), "must be a 12-digit AWS account ID")),
+			mapvalidator.ValueStringsAre(roleArnValidator),
+		},
+	}
+}
+
+// distributionClients assumes the role configured for each distribution
+// roles contains the role to assume indexed by distribution ID; the remaining distributions use the provider's client
+// A distribution whose role cannot be assumed is recorded in Failed so that only that distribution fails
+func distributionClients(ctx context.Context, client *conns.Client, roles map[string]awsbase.AssumeRole) (cloudfront.Clients, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clients := cloudfront.Clients{Default: client, Distributions: map[string]*conns.Client{}, Failed: map[string]diag.Diagnostics{}}
+	for _, distributionId := range sortedKeys(roles) {
+		assumed, d := client.AssumeRole(ctx, roles[distributionId])
+		if d.HasError() {
+			clients.Failed[distributionId] = d
+			continue
+		}
+		for _, cur := range d.Warnings() {
+			diags.AddWarning(fmt.Sprintf("%s (distribution %s)", cur.Summary(), distributionId), cur.Detail())
+		}
+		clients.Distributions[distributionId] = assumed
+	}
+	return clients, diags
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/aws-sdk-go-base/v2/servicemocks"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testDeniedRoleArn = "arn:aws:iam::333333333333:role/denied"
+
+// testStsServer responds to AssumeRole with credentials, or AccessDenied for testDeniedRoleArn
+func testStsServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error parsing STS request: %s", err)
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		if r.PostForm.Get("RoleArn") == testDeniedRoleArn {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(servicemocks.MockStsAssumeRoleInvalidResponseBodyInvalidClientTokenId))
+			return
+		}
+		w.Write([]byte(servicemocks.MockStsAssumeRoleValidResponseBody))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCloudfrontDistributionInvalidationsClients(t *testing.T) {
+	ctx := context.Background()
+	server := testStsServer(t)
+	client := &conns.Client{BaseConfig: awsbase.Config{
+		AccessKey:                     servicemocks.MockStaticAccessKey,
+		SecretKey:                     servicemocks.MockStaticSecretKey,
+		Region:                        "us-east-1",
+		StsEndpoint:                   server.URL,
+		SkipCredsValidation:           true,
+		SkipRequestingAccountId:       true,
+		EC2MetadataServiceEnableState: imds.ClientDisabled,
+		MaxRetries:                    1,
+	}}
+
+	distributionIds, _ := types.SetValueFrom(ctx, types.StringType, []string{
+		"E1111111111111",
+		"arn:aws:cloudfront::111111111111:distribution/E2222222222222",
+		"arn:aws:cloudfront::999999999999:distribution/E3333333333333",
+	})
+	assumeRole := func(roleArn string) types.Object {
+		return types.ObjectValueMust(DistributionAssumeRoleModel{}.AttrTypes(), map[string]attr.Value{
+			"external_id":  types.StringNull(),
+			"role_arn":     types.StringValue(roleArn),
+			"session_name": types.StringNull(),
+		})
+	}
+	distributions, _ := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: CloudfrontDistributionInvalidationsDistributionModel{}.AttrTypes()},
+		map[string]CloudfrontDistributionInvalidationsDistributionModel{
+			"arn:aws:cloudfront::111111111111:distribution/E4444444444444": {
+				AssumeRole: assumeRole("arn:aws:iam::111111111111:role/distribution"),
+				Paths:      types.SetNull(types.StringType),
+			},
+			"E5555555555555": {
+				AssumeRole: assumeRole(testDeniedRoleArn),
+				Paths:      types.SetNull(types.StringType),
+			},
+		})
+	accountRoles, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"111111111111": "arn:aws:iam::111111111111:role/account",
+	})
+
+	r := &CloudfrontDistributionInvalidationsResource{client: client}
+	clients, diags := r.clients(ctx, CloudfrontDistributionInvalidationsModel{
+		AccountRoles:    accountRoles,
+		DistributionIds: distributionIds,
+		Distributions:   distributions,
+	})
+	if diags.HasError() {
+		t.Fatalf("expected a role that cannot be assumed to only fail its distribution, got %v", diags)
+	}
+
+	tests := map[string]string{
+		// Configured by ID, so the provider's credentials are used
+		"E1111111111111": "",
+		// Configured by ARN in an account with a role in `account_roles`
+		"E2222222222222": "arn:aws:iam::111111111111:role/account",
+		// Configured by ARN in an account without a role in `account_roles`
+		"E3333333333333": "",
+		// `assume_role` takes precedence over `account_roles`
+		"E4444444444444": "arn:aws:iam::111111111111:role/distribution",
+	}
+	for distributionId, roleArn := range tests {
+		cur := clients.For(distributionId)
+		if roleArn == "" {
+			if cur != client {
+				t.Errorf("expected %s to use the provider's client", distributionId)
+			}
+			continue
+		}
+		if cur == client || len(cur.BaseConfig.AssumeRole) != 1 || cur.BaseConfig.AssumeRole[0].RoleARN != roleArn {
+			t.Errorf("expected %s to assume %s", distributionId, roleArn)
+		}
+	}
+	if _, ok := clients.Failed["E5555555555555"]; !ok || len(clients.Failed) != 1 {
+		t.Errorf("expected only E5555555555555 to fail, got %v", clients.Failed)
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+)
+
+// DistributionSelectorModel describes the distribution_selector attribute of the invalidation resources
+type DistributionSelectorModel struct {
+	Alias        types.String `tfsdk:"alias"`
+	OriginDomain types.String `tfsdk:"origin_domain"`
+	Tags         types.Map    `tfsdk:"tags"`
+}
+
+func (m DistributionSelectorModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"alias":         types.StringType,
+		"origin_domain": types.StringType,
+		"tags":          types.MapType{ElemType: types.StringType},
+	}
+}
+
+// Selector converts the model into a selector that can be used to find distributions
+func (m DistributionSelectorModel) Selector(ctx context.Context) (cloudfront.DistributionSelector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	selector := cloudfront.DistributionSelector{
+		Alias:        m.Alias.ValueString(),
+		OriginDomain: m.OriginDomain.ValueString(),
+	}
+	if !m.Tags.IsNull() && !m.Tags.IsUnknown() {
+		diags.Append(m.Tags.ElementsAs(ctx, &selector.Tags, false)...)
+	}
+	return selector, diags
+}
+
+// resolveDistributionSelector finds the IDs of the distributions that match a configured distribution_selector
+// An error is returned if no distributions match the selector
+func resolveDistributionSelector(ctx context.Context, client *conns.Client, obj types.Object) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var model DistributionSelectorModel
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	selector, d := model.Selector(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	distributionIds, d := cloudfront.FindDistributionIds(ctx, client, selector)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if len(distributionIds) == 0 {
+		diags.AddAttributeError(path.Root("distribution_selector"), "No AWS Cloudfront Distributions found",
+			"No distributions match `distribution_selector`.")
+	}
+	return distributionIds, diags
+}
+
+func distributionSelectorSchema(description string) schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description + " " +
+			"Distributions are resolved when the invalidation is created and must match every configured criteria. " +
+			"Changing the selector forces a new invalidation; distributions that match the selector later are not invalidated.",
+		Optional: true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "Select distributions that have this alternate domain name (CNAME), ignoring case.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AtLeastOneOf(
+						path.MatchRelative().AtParent().AtName("origin_domain"),
+						path.MatchRelative().AtParent().AtName("tags"),
+					),
+				},
+			},
+			"origin_domain": schema.StringAttribute{
+				MarkdownDescription: "Select distributions that have an origin with this domain name, ignoring case (e.g. `my-bucket.s3.us-east-1.amazonaws.com`).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tags": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Select distributions that have every one of these tags with the same values.",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"regexp"
+)
+
+func endpointsSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "Custom endpoints for AWS services. " +
+			"Use this to point the provider at a local emulator, a VPC endpoint, or a FIPS endpoint.",
+		Attributes: map[string]schema.Attribute{
+			"cloudfront": endpointAttribute("CloudFront"),
+			"iam":        endpointAttribute("IAM"),
+			"sso":        endpointAttribute("SSO"),
+			"sts":        endpointAttribute("STS"),
+		},
+	}
+}
+
+func endpointAttribute(serviceName string) schema.Attribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Use this to override the default service endpoint URL for " + serviceName + ".",
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be a valid http(s) URL"),
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+)
+
+// impactWarning summarizes the invalidations that will be submitted when a plan is applied
+// This lets reviewers see the cost of a plan, e.g. when a change to `triggers` invalidates `/*` on many distributions
+// note is appended to the detail to describe invalidations that cannot be estimated during plan
+// Nothing is returned when the provider skips invalidations because nothing will be submitted
+func impactWarning(client *conns.Client, action string, impact cloudfront.Impact, note string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client != nil && client.SkipInvalidations {
+		return diags
+	}
+	detail := action + ", " + impact.String()
+	if note != "" {
+		detail += " " + note
+	}
+	diags.AddWarning("AWS Cloudfront Invalidations planned", detail)
+	return diags
+}
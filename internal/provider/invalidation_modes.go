@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+func alwaysInvalidateAttribute() schema.Attribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "When `true`, a new invalidation is created on every apply, even if no other attributes changed. " +
+			"Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+func invalidateOnDestroyAttribute() schema.Attribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "When `true`, the configured paths are invalidated again when the resource is destroyed. " +
+			"Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
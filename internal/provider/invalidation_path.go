@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+)
+
+var (
+	_ validator.String = InvalidationPathValidator{}
+)
+
+// InvalidationPathValidator validates that a string value is a valid CloudFront invalidation path
+// See cloudfront.ValidatePath for the rules that are enforced
+type InvalidationPathValidator struct {
+}
+
+func (v InvalidationPathValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("string must be a valid invalidation path that starts with `/`, is at most %d characters, and only contains `%s` as the last character",
+		cloudfront.MaxPathLength, cloudfront.Wildcard)
+}
+
+func (v InvalidationPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v InvalidationPathValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	value := request.ConfigValue.ValueString()
+	for _, err := range cloudfront.ValidatePath(value) {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid Invalidation Path",
+			fmt.Sprintf("%q (%s) is an invalid invalidation path: %s", request.Path.String(), value, err.Error()))
+	}
+}
+
+// planEffectivePaths plans the URL-encoded and collapsed paths so that the plan shows what is submitted to CloudFront
+// `paths` keeps its configured value, since a plan may not change a configured value
+func planEffectivePaths(ctx context.Context, paths types.Set, collapseThreshold types.Int64) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if paths.IsNull() || paths.IsUnknown() || collapseThreshold.IsUnknown() {
+		return types.SetUnknown(types.StringType), diags
+	}
+	cur := make([]string, 0)
+	diags.Append(paths.ElementsAs(ctx, &cur, false)...)
+	if diags.HasError() {
+		return types.SetUnknown(types.StringType), diags
+	}
+	effectivePaths, d := types.SetValueFrom(ctx, types.StringType, cloudfront.EffectivePaths(cur, int(collapseThreshold.ValueInt64())))
+	diags.Append(d...)
+	return effectivePaths, diags
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+)
+
+func pathsFileAttribute(validators ...validator.String) schema.Attribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The path to a file that contains the paths to invalidate instead of `paths`. " +
+			"The file contains either a JSON array of paths or one path per line. " +
+			"The file is read during plan and its paths are recorded in `paths`; when the contents of the file change, a new invalidation is created.",
+		Optional:   true,
+		Validators: validators,
+	}
+}
+
+func pathsFileHashAttribute() schema.Attribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The SHA-256 hash of the contents of `paths_file`.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// usePathsStateWhenNotConfigured plans the prior `paths` when `paths` is not configured
+// Otherwise, any change to the resource would plan unknown paths and force replacement
+// The paths from `paths_file` are planned by planPathsFile once the file is read
+type usePathsStateWhenNotConfigured struct{}
+
+func (m usePathsStateWhenNotConfigured) Description(ctx context.Context) string {
+	return "Uses the prior paths when paths are not configured."
+}
+
+func (m usePathsStateWhenNotConfigured) MarkdownDescription(ctx context.Context) string {
+	return "Uses the prior `paths` when `paths` are not configured."
+}
+
+func (m usePathsStateWhenNotConfigured) PlanModifySet(ctx context.Context, request planmodifier.SetRequest, response *planmodifier.SetResponse) {
+	if request.State.Raw.IsNull() || request.Plan.Raw.IsNull() || !request.ConfigValue.IsNull() {
+		return
+	}
+	response.PlanValue = request.StateValue
+}
+
+// planPathsFile plans `paths` and `paths_file_hash` from the planned `paths_file`
+// If `paths_file` is not configured, the configured `paths` are planned and the hash is null
+// Otherwise, the file is read so that its paths are shown in the plan and validated like configured paths
+func planPathsFile(ctx context.Context, pathsFile types.String, configPaths types.Set) (types.Set, types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if pathsFile.IsNull() {
+		return configPaths, types.StringNull(), diags
+	}
+	if pathsFile.IsUnknown() {
+		return types.SetUnknown(types.StringType), types.StringUnknown(), diags
+	}
+
+	paths, hash, err := cloudfront.ReadPathsFile(pathsFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("paths_file"), "Unable to read paths file", err.Error())
+		return types.SetUnknown(types.StringType), types.StringUnknown(), diags
+	}
+	if len(paths) == 0 {
+		diags.AddAttributeError(path.Root("paths_file"), "Invalid paths file",
+			fmt.Sprintf("%s does not contain any paths.", pathsFile.ValueString()))
+	}
+	for _, cur := range paths {
+		for _, err := range cloudfront.ValidatePath(cur) {
+			diags.AddAttributeError(path.Root("paths_file"), "Invalid invalidation path",
+				fmt.Sprintf("%s contains an invalid path %q: %s", pathsFile.ValueString(), cur, err))
+		}
+	}
+	if diags.HasError() {
+		return types.SetUnknown(types.StringType), types.StringUnknown(), diags
+	}
+
+	planned, d := types.SetValueFrom(ctx, types.StringType, paths)
+	diags.Append(d...)
+	return planned, types.StringValue(hash), diags
+}
+
+// pathsFileChanged returns true if the contents of `paths_file` differ from the contents when the invalidation was created
+// An unknown hash is assumed to have changed because the file cannot be read until apply
+func pathsFileChanged(state types.String, plan types.String) bool {
+	if plan.IsUnknown() {
+		return true
+	}
+	return !plan.IsNull() && !plan.Equal(state)
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-awsex/internal/provider/cloudfront"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanPathsNotConfigured(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "paths.txt")
+	if err := os.WriteFile(name, []byte("/index.html\n/about.html\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing paths file: %s", err)
+	}
+	_, hash, err := cloudfront.ReadPathsFile(name)
+	if err != nil {
+		t.Fatalf("unexpected error reading paths file: %s", err)
+	}
+
+	tests := map[string]struct {
+		typeName    string
+		state       map[string]tftypes.Value
+		config      map[string]tftypes.Value
+		contents    string
+		wantReplace bool
+		wantPaths   tftypes.Value
+	}{
+		"paths_file unchanged": {
+			typeName: "awsex_cloudfront_distribution_invalidation",
+			state: map[string]tftypes.Value{
+				"paths":           testStringSet("/about.html", "/index.html"),
+				"paths_file":      testString(name),
+				"paths_file_hash": testString(hash),
+			},
+			config: map[string]tftypes.Value{
+				"paths_file":          testString(name),
+				"wait_for_completion": testBool(false),
+			},
+			wantPaths: testStringSet("/about.html", "/index.html"),
+		},
+		"paths_file changed": {
+			typeName: "awsex_cloudfront_distribution_invalidation",
+			state: map[string]tftypes.Value{
+				"paths":           testStringSet("/about.html", "/index.html"),
+				"paths_file":      testString(name),
+				"paths_file_hash": testString(hash),
+			},
+			config: map[string]tftypes.Value{
+				"paths_file": testString(name),
+			},
+			contents:    "/*\n",
+			wantReplace: true,
+			wantPaths:   testStringSet("/*"),
+		},
+		"distributions only": {
+			typeName: "awsex_cloudfront_distribution_invalidations",
+			state: map[string]tftypes.Value{
+				"distributions": testDistributions(map[string][]string{"E1111111111111": {"/index.html"}}, nil),
+			},
+			config: map[string]tftypes.Value{
+				"distributions":       testDistributions(map[string][]string{"E1111111111111": {"/index.html"}}, nil),
+				"wait_for_completion": testBool(false),
+			},
+			wantPaths: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := testCloudfrontDistributionInvalidationState()
+			config := map[string]tftypes.Value{"distribution_id": testString("E1111111111111")}
+			if test.typeName == "awsex_cloudfront_distribution_invalidations" {
+				state = testCloudfrontDistributionInvalidationsState()
+				delete(state, "distribution_ids")
+				config = map[string]tftypes.Value{}
+			}
+			delete(state, "paths")
+			for key, value := range test.state {
+				state[key] = value
+			}
+			for key, value := range test.config {
+				config[key] = value
+			}
+			if test.contents != "" {
+				changed := filepath.Join(t.TempDir(), "paths.txt")
+				if err := os.WriteFile(changed, []byte(test.contents), 0o600); err != nil {
+					t.Fatalf("unexpected error writing paths file: %s", err)
+				}
+				config["paths_file"] = testString(changed)
+			}
+
+			plan := testPlanResourceChange(t, test.typeName, state, config)
+			if replace := len(plan.RequiresReplace) > 0; replace != test.wantReplace {
+				t.Errorf("expected replace %t, got %v", test.wantReplace, plan.RequiresReplace)
+			}
+			if got := plan.Attribute(t, "paths"); !got.Equal(test.wantPaths) {
+				t.Errorf("expected paths %s, got %s", test.wantPaths, got)
+			}
+		})
+	}
+}
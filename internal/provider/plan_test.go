@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"testing"
+)
+
+// testPlan is the result of planning a resource through the provider server
+type testPlan struct {
+	Planned         tftypes.Value
+	PlannedPrivate  []byte
+	RequiresReplace []*tftypes.AttributePath
+	Diagnostics     []*tfprotov6.Diagnostic
+}
+
+// testState is the state of a resource after applying or refreshing it through the provider server
+type testState struct {
+	State       tftypes.Value
+	Private     []byte
+	Diagnostics []*tfprotov6.Diagnostic
+}
+
+// testProvider configures resources and data sources with client instead of the provider configuration
+type testProvider struct {
+	provider.Provider
+	client *conns.Client
+}
+
+func (p testProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
+	response.DataSourceData = p.client
+	response.ResourceData = p.client
+}
+
+// testProviderServer plans and applies resources the way Terraform does
+type testProviderServer struct {
+	server  tfprotov6.ProviderServer
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// newTestProviderServer returns a provider server whose resources use client, or an unconfigured provider if client is nil
+func newTestProviderServer(t *testing.T, client *conns.Client) *testProviderServer {
+	t.Helper()
+	ctx := context.Background()
+	p := New("test")()
+	if client != nil {
+		p = testProvider{Provider: p, client: client}
+	}
+	server, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %s", err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error getting provider schema: %s", err)
+	}
+	if client != nil {
+		if _, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{}); err != nil {
+			t.Fatalf("unexpected error configuring provider: %s", err)
+		}
+	}
+	return &testProviderServer{server: server, schemas: schemas}
+}
+
+// testPlanResourceChange plans a change from prior (nil to create) to config the way Terraform does
+// Attributes that are missing from prior or config are null
+func testPlanResourceChange(t *testing.T, typeName string, prior, config map[string]tftypes.Value) testPlan {
+	t.Helper()
+	return newTestProviderServer(t, nil).Plan(t, typeName, prior, nil, config)
+}
+
+func (s *testProviderServer) schema(t *testing.T, typeName string) (*tfprotov6.Schema, tftypes.Object) {
+	t.Helper()
+	schema, ok := s.schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("unknown resource type %q", typeName)
+	}
+	return schema, schema.ValueType().(tftypes.Object)
+}
+
+func (s *testProviderServer) dynamicValue(t *testing.T, typ tftypes.Object, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	result, err := tfprotov6.NewDynamicValue(typ, value)
+	if err != nil {
+		t.Fatalf("unexpected error encoding value: %s", err)
+	}
+	return &result
+}
+
+// Plan plans a change from prior (nil to create) with its private state to config
+func (s *testProviderServer) Plan(t *testing.T, typeName string, prior map[string]tftypes.Value, priorPrivate []byte, config map[string]tftypes.Value) testPlan {
+	t.Helper()
+	schema, typ := s.schema(t, typeName)
+
+	priorValue := tftypes.NewValue(typ, nil)
+	if prior != nil {
+		priorValue = testObjectValue(typ, prior)
+	}
+	// Terraform proposes the configured value, or the prior value of a computed attribute that is not configured
+	proposed := map[string]tftypes.Value{}
+	for _, attribute := range schema.Block.Attributes {
+		value, ok := config[attribute.Name]
+		if (!ok || value.IsNull()) && attribute.Computed && prior != nil {
+			value, ok = prior[attribute.Name]
+		}
+		if ok {
+			proposed[attribute.Name] = value
+		}
+	}
+	for _, block := range schema.Block.BlockTypes {
+		if value, ok := config[block.TypeName]; ok {
+			proposed[block.TypeName] = value
+		}
+	}
+
+	response, err := s.server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       s.dynamicValue(t, typ, priorValue),
+		PriorPrivate:     priorPrivate,
+		ProposedNewState: s.dynamicValue(t, typ, testObjectValue(typ, proposed)),
+		Config:           s.dynamicValue(t, typ, testObjectValue(typ, config)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error planning %s: %s", typeName, err)
+	}
+	for _, d := range response.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error planning %s: %s: %s", typeName, d.Summary, d.Detail)
+		}
+	}
+	planned, err := response.PlannedState.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("unexpected error decoding planned state: %s", err)
+	}
+	return testPlan{Planned: planned, PlannedPrivate: response.PlannedPrivate, RequiresReplace: response.RequiresReplace, Diagnostics: response.Diagnostics}
+}
+
+// Apply plans and applies a change from prior (nil to create) with its private state to config
+func (s *testProviderServer) Apply(t *testing.T, typeName string, prior map[string]tftypes.Value, priorPrivate []byte, config map[string]tftypes.Value) testState {
+	t.Helper()
+	_, typ := s.schema(t, typeName)
+	plan := s.Plan(t, typeName, prior, priorPrivate, config)
+
+	priorValue := tftypes.NewValue(typ, nil)
+	if prior != nil {
+		priorValue = testObjectValue(typ, prior)
+	}
+	response, err := s.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     s.dynamicValue(t, typ, priorValue),
+		PlannedState:   s.dynamicValue(t, typ, plan.Planned),
+		PlannedPrivate: plan.PlannedPrivate,
+		Config:         s.dynamicValue(t, typ, testObjectValue(typ, config)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error applying %s: %s", typeName, err)
+	}
+	state, err := response.NewState.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("unexpected error decoding new state: %s", err)
+	}
+	return testState{State: state, Private: response.Private, Diagnostics: response.Diagnostics}
+}
+
+// Read refreshes a resource from state with its private state
+func (s *testProviderServer) Read(t *testing.T, typeName string, state map[string]tftypes.Value, private []byte) testState {
+	t.Helper()
+	_, typ := s.schema(t, typeName)
+	response, err := s.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: s.dynamicValue(t, typ, testObjectValue(typ, state)),
+		Private:      private,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error reading %s: %s", typeName, err)
+	}
+	newState, err := response.NewState.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("unexpected error decoding new state: %s", err)
+	}
+	return testState{State: newState, Private: response.Private, Diagnostics: response.Diagnostics}
+}
+
+// Attributes returns the top-level attributes of the state
+func (a testState) Attributes(t *testing.T) map[string]tftypes.Value {
+	t.Helper()
+	attributes := map[string]tftypes.Value{}
+	if err := a.State.As(&attributes); err != nil {
+		t.Fatalf("unexpected error decoding state: %s", err)
+	}
+	return attributes
+}
+
+// Attribute returns the planned value of a top-level attribute
+func (p testPlan) Attribute(t *testing.T, name string) tftypes.Value {
+	t.Helper()
+	attributes := map[string]tftypes.Value{}
+	if err := p.Planned.As(&attributes); err != nil {
+		t.Fatalf("unexpected error decoding planned state: %s", err)
+	}
+	return attributes[name]
+}
+
+// Warning returns the detail of the plan's warning with summary
+func (p testPlan) Warning(summary string) (string, bool) {
+	return testWarning(p.Diagnostics, summary)
+}
+
+// Warning returns the detail of the warning with summary
+func (a testState) Warning(summary string) (string, bool) {
+	return testWarning(a.Diagnostics, summary)
+}
+
+// CheckErrors fails the test if applying or refreshing the resource returned an error
+func (a testState) CheckErrors(t *testing.T) {
+	t.Helper()
+	for _, d := range a.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func testWarning(diagnostics []*tfprotov6.Diagnostic, summary string) (string, bool) {
+	for _, d := range diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityWarning && d.Summary == summary {
+			return d.Detail, true
+		}
+	}
+	return "", false
+}
+
+func testObjectValue(typ tftypes.Object, attributes map[string]tftypes.Value) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	return tftypes.NewValue(typ, values)
+}
+
+func testString(value string) tftypes.Value {
+	return tftypes.NewValue(tftypes.String, value)
+}
+
+func testBool(value bool) tftypes.Value {
+	return tftypes.NewValue(tftypes.Bool, value)
+}
+
+func testStringSet(values ...string) tftypes.Value {
+	elements := make([]tftypes.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, testString(value))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+}
+
+func testStringList(values ...string) tftypes.Value {
+	elements := make([]tftypes.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, testString(value))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+}
+
+func testStringMap(values map[string]string) tftypes.Value {
+	elements := map[string]tftypes.Value{}
+	for key, value := range values {
+		elements[key] = testString(value)
+	}
+	return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+}
@@ -3,12 +3,14 @@ package provider
 import (
 	"context"
 	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
@@ -60,6 +62,8 @@ This can also be used to rapidly experiment with new resources.
 					"Can also be configured using the `AWS_CA_BUNDLE` environment variable. " +
 					"(Setting `ca_bundle` in the shared config file is not supported.)",
 			},
+			"default_timeouts": defaultTimeoutsSchema(),
+			"endpoints":        endpointsSchema(),
 			"http_proxy": schema.StringAttribute{
 				Optional: true,
 				Description: "URL of a proxy to use for HTTP requests when accessing the AWS API. " +
@@ -75,6 +79,12 @@ This can also be used to rapidly experiment with new resources.
 				Description: "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted, " +
 					"default value is `false`",
 			},
+			"max_concurrency": schema.Int32Attribute{
+				Optional: true,
+				Description: "The default maximum number of concurrent API operations performed by a single resource " +
+					"(e.g. distributions invalidated at once). Defaults to `10`.",
+				Validators: []validator.Int32{int32validator.AtLeast(1)},
+			},
 			"max_retries": schema.Int32Attribute{
 				Optional: true,
 				Description: "The maximum number of times an AWS API request is\n" +
@@ -91,6 +101,12 @@ This can also be used to rapidly experiment with new resources.
 				Description: "The profile for API operations. If not set, the default profile\n" +
 					"created with `aws configure` will be used.",
 			},
+			"rate_limit": schema.Float64Attribute{
+				Optional: true,
+				Description: "The maximum number of AWS API requests per second shared by every resource using this provider. " +
+					"Retries and polling while waiting for an operation count against the limit. If omitted, requests are not rate limited.",
+				Validators: []validator.Float64{float64validator.AtLeast(0.1)},
+			},
 			"region": schema.StringAttribute{
 				Optional: true,
 				Description: "The region where AWS operations will take place. Examples\n" +
@@ -116,6 +132,13 @@ This can also be used to rapidly experiment with new resources.
 				Description: "List of paths to shared credentials files. If not set, defaults to [~/.aws/credentials].",
 				ElementType: types.StringType,
 			},
+			"skip_cloudfront_invalidations": schema.BoolAttribute{
+				Optional: true,
+				Description: "When `true`, invalidation resources record a `Skipped` status instead of creating invalidations. " +
+					"Use this in environments where invalidating is wasted time and money (e.g. preview environments). " +
+					"Invalidations that were skipped are not created when this is disabled later. " +
+					"Can also be configured using the `" + skipCloudfrontInvalidationsEnvVar + "` environment variable. Defaults to `false`.",
+			},
 			"token": schema.StringAttribute{
 				Optional: true,
 				Description: "session token. A session token is only required if you are\n" +
@@ -135,19 +158,28 @@ func (p *AwsexProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	tflog.Debug(ctx, "Configuring Terraform AWS Provider")
 	awsbaseConfig := model.GetAwsBaseConfig(p.version, req.TerraformVersion)
 	ctx, cfg, basediags := awsbase.GetAwsConfig(ctx, &awsbaseConfig)
-	for _, d := range basediags {
-		switch int(d.Severity()) {
-		case int(diag.SeverityError):
-			resp.Diagnostics.AddError(d.Summary(), d.Detail())
-		case int(diag.SeverityWarning):
-			resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
-		}
+	resp.Diagnostics.Append(conns.FromAwsbaseDiags(basediags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	skipInvalidations, diags := model.GetSkipCloudfrontInvalidations()
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client := &conns.Client{Config: cfg}
+	client := &conns.Client{
+		Config:            cfg,
+		BaseConfig:        awsbaseConfig,
+		Endpoints:         model.Endpoints.ServiceEndpoints(),
+		MaxConcurrency:    unptr(model.MaxConcurrency),
+		DefaultTimeouts:   model.DefaultTimeouts.Timeouts(),
+		SkipInvalidations: skipInvalidations,
+	}
+	if model.RateLimit != nil {
+		client.RateLimiter = conns.NewRateLimiter(*model.RateLimit)
+	}
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -159,11 +191,14 @@ func (p *AwsexProvider) Resources(ctx context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		NewCloudfrontDistributionInvalidationResource,
 		NewCloudfrontDistributionInvalidationsResource,
+		NewCloudfrontDistributionManifestInvalidationResource,
 	}
 }
 
 func (p *AwsexProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewCloudfrontDistributionInvalidationsDataSource,
+	}
 }
 
 func (p *AwsexProvider) Functions(ctx context.Context) []func() function.Function {
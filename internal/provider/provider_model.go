@@ -1,10 +1,16 @@
 package provider
 
 import (
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsbase "github.com/hashicorp/aws-sdk-go-base/v2"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"os"
+	"strconv"
 )
 
 type AwsexProviderModel struct {
@@ -18,6 +24,12 @@ type AwsexProviderModel struct {
 	// Can also be configured using the `AWS_CA_BUNDLE` environment variable.
 	// (Setting `ca_bundle` in the shared config file is not supported.)
 	CustomCaBundle *string `tfsdk:"custom_ca_bundle"`
+	// DefaultTimeouts
+	// Default timeouts for resource operations.
+	DefaultTimeouts *AwsexDefaultTimeoutsModel `tfsdk:"default_timeouts"`
+	// Endpoints
+	// Custom endpoints for AWS services.
+	Endpoints *AwsexEndpointsModel `tfsdk:"endpoints"`
 	// HttpProxy
 	// URL of a proxy to use for HTTP requests when accessing the AWS API.
 	// Can also be set using the `HTTP_PROXY` or `http_proxy` environment variables.
@@ -30,6 +42,9 @@ type AwsexProviderModel struct {
 	// Explicitly allow the provider to perform "insecure" SSL requests.
 	// If omitted, default value is `false`
 	Insecure *bool `tfsdk:"insecure"`
+	// MaxConcurrency
+	// The default maximum number of concurrent API operations performed by a single resource.
+	MaxConcurrency *int `tfsdk:"max_concurrency"`
 	// MaxRetries
 	// The maximum number of times an AWS API request is being executed.
 	// If the API request still fails, an error is thrown.
@@ -41,6 +56,9 @@ type AwsexProviderModel struct {
 	// Profile
 	// The profile for API operations. If not set, the default profile created with `aws configure` will be used.
 	Profile *string `tfsdk:"profile"`
+	// RateLimit
+	// The maximum number of AWS API requests per second shared by every resource using this provider.
+	RateLimit *float64 `tfsdk:"rate_limit"`
 	// Region
 	// The region where AWS operations will take place.
 	// Examples are us-east-1, us-west-2, etc.
@@ -58,6 +76,10 @@ type AwsexProviderModel struct {
 	// SharedCredentialsFiles
 	// List of paths to shared credentials files. If not set, defaults to [~/.aws/credentials].
 	SharedCredentialsFiles []string `tfsdk:"shared_credentials_files"`
+	// SkipCloudfrontInvalidations
+	// Record invalidations as skipped instead of creating them.
+	// Can also be configured using the `AWSEX_SKIP_CLOUDFRONT_INVALIDATIONS` environment variable.
+	SkipCloudfrontInvalidations *bool `tfsdk:"skip_cloudfront_invalidations"`
 	// Token
 	// session token. A session token is only required if you are using temporary security credentials.
 	Token *string `tfsdk:"token"`
@@ -94,6 +116,7 @@ func (m AwsexProviderModel) GetAwsBaseConfig(providerVersion, terraformVersion s
 	}
 	m.AssumeRole.Configure(&awsbaseConfig)
 	m.AssumeRoleWithWebIdentity.Configure(&awsbaseConfig)
+	m.Endpoints.Configure(&awsbaseConfig)
 	if len(m.SharedConfigFiles) != 0 {
 		awsbaseConfig.SharedConfigFiles = m.SharedConfigFiles
 	}
@@ -103,6 +126,28 @@ func (m AwsexProviderModel) GetAwsBaseConfig(providerVersion, terraformVersion s
 	return awsbaseConfig
 }
 
+// skipCloudfrontInvalidationsEnvVar configures `skip_cloudfront_invalidations` when it is not set in the provider configuration
+const skipCloudfrontInvalidationsEnvVar = "AWSEX_SKIP_CLOUDFRONT_INVALIDATIONS"
+
+// GetSkipCloudfrontInvalidations returns `skip_cloudfront_invalidations`, falling back to skipCloudfrontInvalidationsEnvVar
+func (m AwsexProviderModel) GetSkipCloudfrontInvalidations() (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.SkipCloudfrontInvalidations != nil {
+		return *m.SkipCloudfrontInvalidations, diags
+	}
+	raw := os.Getenv(skipCloudfrontInvalidationsEnvVar)
+	if raw == "" {
+		return false, diags
+	}
+	skip, err := strconv.ParseBool(raw)
+	if err != nil {
+		diags.AddAttributeError(path.Root("skip_cloudfront_invalidations"), "Invalid environment variable",
+			fmt.Sprintf("%s must be a boolean, got %q.", skipCloudfrontInvalidationsEnvVar, raw))
+	}
+	return skip, diags
+}
+
 type AwsexAssumeRoleModel struct {
 	Duration          timetypes.GoDuration `tfsdk:"duration"`
 	ExternalId        string               `tfsdk:"external_id"`
@@ -165,6 +210,35 @@ func (m *AwsexAssumeRoleWithWebIdentityModel) Configure(cfg *awsbase.Config) {
 	}
 }
 
+type AwsexEndpointsModel struct {
+	Cloudfront *string `tfsdk:"cloudfront"`
+	Iam        *string `tfsdk:"iam"`
+	Sso        *string `tfsdk:"sso"`
+	Sts        *string `tfsdk:"sts"`
+}
+
+// Configure sets the endpoints that are used by awsbase while resolving credentials
+func (m *AwsexEndpointsModel) Configure(cfg *awsbase.Config) {
+	if m == nil {
+		return
+	}
+
+	cfg.IamEndpoint = unptr(m.Iam)
+	cfg.SsoEndpoint = unptr(m.Sso)
+	cfg.StsEndpoint = unptr(m.Sts)
+}
+
+// ServiceEndpoints returns the base endpoint overrides for each service client in conns.Client
+func (m *AwsexEndpointsModel) ServiceEndpoints() conns.Endpoints {
+	if m == nil {
+		return conns.Endpoints{}
+	}
+
+	return conns.Endpoints{
+		Cloudfront: unptr(m.Cloudfront),
+	}
+}
+
 func unptr[T any](val *T) T {
 	var t T
 	if val != nil {
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-provider-awsex/internal/conns"
+	"testing"
+)
+
+func TestAwsexEndpointsModel(t *testing.T) {
+	model := AwsexProviderModel{
+		Region: aws.String("us-east-1"),
+		Endpoints: &AwsexEndpointsModel{
+			Cloudfront: aws.String("http://localhost:4566/cloudfront"),
+			Iam:        aws.String("http://localhost:4566/iam"),
+			Sso:        aws.String("http://localhost:4566/sso"),
+			Sts:        aws.String("http://localhost:4566/sts"),
+		},
+	}
+
+	baseConfig := model.GetAwsBaseConfig("test", "1.0.0")
+	if baseConfig.IamEndpoint != "http://localhost:4566/iam" || baseConfig.SsoEndpoint != "http://localhost:4566/sso" || baseConfig.StsEndpoint != "http://localhost:4566/sts" {
+		t.Errorf("expected the IAM, SSO, and STS endpoints to be used to resolve credentials, got %q, %q, and %q",
+			baseConfig.IamEndpoint, baseConfig.SsoEndpoint, baseConfig.StsEndpoint)
+	}
+
+	client := &conns.Client{Config: aws.Config{Region: "us-east-1"}, Endpoints: model.Endpoints.ServiceEndpoints()}
+	if endpoint := aws.ToString(client.Cloudfront().Options().BaseEndpoint); endpoint != "http://localhost:4566/cloudfront" {
+		t.Errorf("expected the CloudFront client to use the cloudfront endpoint, got %q", endpoint)
+	}
+
+	model.Endpoints = nil
+	client = &conns.Client{Config: aws.Config{Region: "us-east-1"}, Endpoints: model.Endpoints.ServiceEndpoints()}
+	if endpoint := client.Cloudfront().Options().BaseEndpoint; endpoint != nil {
+		t.Errorf("expected the CloudFront client to use the default endpoint, got %q", aws.ToString(endpoint))
+	}
+}
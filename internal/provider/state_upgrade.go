@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// upgradeTimeouts converts a timeouts block from a prior schema version to a block with the attributes in names
+// Timeouts that are missing from the prior block are null
+func upgradeTimeouts(ctx context.Context, prior timeouts.Value, names ...string) (timeouts.Value, diag.Diagnostics) {
+	attrTypes := map[string]attr.Type{}
+	for _, name := range names {
+		attrTypes[name] = types.StringType
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return timeouts.Value{Object: types.ObjectNull(attrTypes)}, nil
+	}
+
+	priorAttrs := prior.Attributes()
+	attrs := map[string]attr.Value{}
+	for _, name := range names {
+		attrs[name] = types.StringNull()
+		if cur, ok := priorAttrs[name]; ok {
+			attrs[name] = cur
+		}
+	}
+	obj, diags := types.ObjectValue(attrTypes, attrs)
+	return timeouts.Value{Object: obj}, diags
+}